@@ -0,0 +1,247 @@
+package helper
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// CertificateInfo describes the leaf of a PEM certificate chain
+type CertificateInfo struct {
+	Subject           string
+	Issuer            string
+	SANs              []string
+	NotAfter          time.Time
+	FingerprintSHA256 string
+}
+
+// Attributes returns the certificate details keyed by the computed attribute names used by
+// certificate resources
+func (info *CertificateInfo) Attributes() map[string]interface{} {
+	return map[string]interface{}{
+		"subject":            info.Subject,
+		"issuer":             info.Issuer,
+		"sans":               info.SANs,
+		"not_after":          info.NotAfter.UTC().Format(time.RFC3339),
+		"fingerprint_sha256": info.FingerprintSHA256,
+	}
+}
+
+// normalizePEM converts escaped newlines, as returned by some APIs, to real newlines
+func normalizePEM(value string) string {
+	return strings.TrimSpace(strings.ReplaceAll(value, "\\n", "\n"))
+}
+
+// stripWhitespace removes all whitespace, used to compare PEM content that cannot be parsed
+func stripWhitespace(value string) string {
+	return strings.Join(strings.Fields(normalizePEM(value)), "")
+}
+
+// ParseCertificateChain parses a PEM encoded certificate chain, leaf first
+func ParseCertificateChain(certPEM string) ([]*x509.Certificate, error) {
+	rest := []byte(normalizePEM(certPEM))
+	chain := make([]*x509.Certificate, 0)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block %q in certificate, only CERTIFICATE blocks are allowed", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate %d: %w", len(chain), err)
+		}
+		chain = append(chain, cert)
+	}
+
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return nil, fmt.Errorf("unexpected data after certificate %d", len(chain)-1)
+	}
+	return chain, nil
+}
+
+// GetCertificateInfo returns the details of the leaf certificate of a chain
+func GetCertificateInfo(chain []*x509.Certificate) *CertificateInfo {
+	leaf := chain[0]
+	sans := make([]string, 0, len(leaf.DNSNames)+len(leaf.IPAddresses))
+	sans = append(sans, leaf.DNSNames...)
+	for _, ip := range leaf.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+
+	return &CertificateInfo{
+		Subject:           leaf.Subject.String(),
+		Issuer:            leaf.Issuer.String(),
+		SANs:              sans,
+		NotAfter:          leaf.NotAfter,
+		FingerprintSHA256: certificateFingerprint(leaf),
+	}
+}
+
+func certificateFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidateCertificateChain checks that no certificate in the chain has expired, that each
+// certificate is issued by the one following it and that the chain leads to a trusted root or
+// ends with a self-signed certificate
+func ValidateCertificateChain(chain []*x509.Certificate, now time.Time) error {
+	for i, cert := range chain {
+		if now.After(cert.NotAfter) {
+			return fmt.Errorf("certificate %d (%s) expired at %s", i, cert.Subject, cert.NotAfter.UTC().Format(time.RFC3339))
+		}
+		if now.Before(cert.NotBefore) {
+			return fmt.Errorf("certificate %d (%s) is not valid before %s", i, cert.Subject, cert.NotBefore.UTC().Format(time.RFC3339))
+		}
+	}
+
+	for i := 0; i < len(chain)-1; i++ {
+		if chain[i].CheckSignatureFrom(chain[i+1]) == nil {
+			continue
+		}
+		for j, candidate := range chain {
+			if j != i && j != i+1 && chain[i].CheckSignatureFrom(candidate) == nil {
+				return fmt.Errorf("certificate chain is not ordered: certificate %d (%s) is issued by certificate %d (%s), which must directly follow it",
+					i, chain[i].Subject, j, candidate.Subject)
+			}
+		}
+		return fmt.Errorf("certificate chain is incomplete: the issuer %q of certificate %d is missing", chain[i].Issuer, i)
+	}
+
+	last := chain[len(chain)-1]
+	if bytes.Equal(last.RawIssuer, last.RawSubject) && last.CheckSignatureFrom(last) == nil {
+		return nil
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := chain[0].Verify(x509.VerifyOptions{
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	var unknownAuthority x509.UnknownAuthorityError
+	if errors.As(err, &unknownAuthority) {
+		return fmt.Errorf("certificate chain is incomplete: the issuer %q of certificate %d is neither included nor a trusted root", last.Issuer, len(chain)-1)
+	}
+	// Other verification errors, such as missing system roots, are left to the API
+	return nil
+}
+
+// ValidateCertificateKey checks that the private key matches the leaf certificate
+func ValidateCertificateKey(certPEM, keyPEM string) error {
+	if _, err := tls.X509KeyPair([]byte(normalizePEM(certPEM)), []byte(normalizePEM(keyPEM))); err != nil {
+		return fmt.Errorf("private key does not match the certificate: %w", err)
+	}
+	return nil
+}
+
+// ValidateCertificate parses and validates a certificate chain and its private key, and returns
+// the details of the leaf certificate
+func ValidateCertificate(certPEM, keyPEM string, now time.Time) (*CertificateInfo, error) {
+	chain, err := ParseCertificateChain(certPEM)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateCertificateChain(chain, now); err != nil {
+		return nil, err
+	}
+	if keyPEM != "" {
+		if err := ValidateCertificateKey(certPEM, keyPEM); err != nil {
+			return nil, err
+		}
+	}
+	return GetCertificateInfo(chain), nil
+}
+
+// ParseCertificateTime parses a certificate validity time as returned by the APIs, either
+// formatted in APILocation or as a unix timestamp
+func ParseCertificateTime(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), true
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05", time.RFC3339, "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, APILocation); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// SuppressEquivalentCertificate suppresses the diff between two certificate chains with the same
+// fingerprints, falling back to comparing the content without whitespace
+func SuppressEquivalentCertificate(k, old, new string, d *schema.ResourceData) bool {
+	oldChain, oldErr := ParseCertificateChain(old)
+	newChain, newErr := ParseCertificateChain(new)
+	if oldErr != nil || newErr != nil {
+		return stripWhitespace(old) == stripWhitespace(new)
+	}
+
+	if len(oldChain) != len(newChain) {
+		return false
+	}
+	for i := range oldChain {
+		if certificateFingerprint(oldChain[i]) != certificateFingerprint(newChain[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// SuppressEquivalentPrivateKey suppresses the diff between two encodings of the same private key,
+// falling back to comparing the content without whitespace
+func SuppressEquivalentPrivateKey(k, old, new string, d *schema.ResourceData) bool {
+	oldKey, oldErr := publicKeyOf(old)
+	newKey, newErr := publicKeyOf(new)
+	if oldErr != nil || newErr != nil {
+		return stripWhitespace(old) == stripWhitespace(new)
+	}
+	return bytes.Equal(oldKey, newKey)
+}
+
+// publicKeyOf returns the DER encoded public key of a PEM encoded private key
+func publicKeyOf(keyPEM string) ([]byte, error) {
+	block, _ := pem.Decode([]byte(normalizePEM(keyPEM)))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded private key found")
+	}
+
+	var key interface{}
+	var err error
+	if key, err = x509.ParsePKCS8PrivateKey(block.Bytes); err != nil {
+		if key, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+			if key, err = x509.ParseECPrivateKey(block.Bytes); err != nil {
+				return nil, fmt.Errorf("unsupported private key format")
+			}
+		}
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return x509.MarshalPKIXPublicKey(signer.Public())
+}
@@ -0,0 +1,172 @@
+package helper
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  string
+}
+
+func newTestCertificate(t *testing.T, name string, parent *testCertificate, isCA bool, notAfter time.Time) *testCertificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	if !isCA {
+		template.DNSNames = []string{name, "www." + name}
+	}
+
+	signerCert, signerKey := template, key
+	if parent != nil {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	return &testCertificate{
+		cert: cert,
+		key:  key,
+		pem:  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+	}
+}
+
+func (c *testCertificate) keyPEM(t *testing.T, pkcs8 bool) string {
+	t.Helper()
+	if pkcs8 {
+		der, err := x509.MarshalPKCS8PrivateKey(c.key)
+		if err != nil {
+			t.Fatalf("failed to marshal key: %v", err)
+		}
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	}
+	der, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+}
+
+func TestValidateCertificate(t *testing.T) {
+	validUntil := time.Now().Add(90 * 24 * time.Hour)
+	root := newTestCertificate(t, "Test Root", nil, true, validUntil)
+	intermediate := newTestCertificate(t, "Test Intermediate", root, true, validUntil)
+	leaf := newTestCertificate(t, "example.com", intermediate, false, validUntil)
+	expired := newTestCertificate(t, "expired.com", intermediate, false, time.Now().Add(-time.Minute))
+	other := newTestCertificate(t, "other.com", intermediate, false, validUntil)
+
+	tests := []struct {
+		name    string
+		cert    string
+		key     string
+		wantErr string
+	}{
+		{"complete chain", leaf.pem + intermediate.pem + root.pem, leaf.keyPEM(t, true), ""},
+		{"escaped newlines", strings.ReplaceAll(leaf.pem+intermediate.pem+root.pem, "\n", "\\n"), leaf.keyPEM(t, false), ""},
+		{"not ordered", leaf.pem + root.pem + intermediate.pem, leaf.keyPEM(t, true), "not ordered"},
+		{"missing intermediate", leaf.pem + root.pem, leaf.keyPEM(t, true), "incomplete"},
+		{"untrusted root", leaf.pem + intermediate.pem, leaf.keyPEM(t, true), "incomplete"},
+		{"expired", expired.pem + intermediate.pem + root.pem, expired.keyPEM(t, true), "expired"},
+		{"key mismatch", leaf.pem + intermediate.pem + root.pem, other.keyPEM(t, true), "does not match"},
+		{"not a certificate", leaf.keyPEM(t, true), "", "only CERTIFICATE blocks"},
+		{"empty", "", "", "no PEM encoded certificate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := ValidateCertificate(tt.cert, tt.key, time.Now())
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if info.Subject != "CN=example.com" || info.Issuer != "CN=Test Intermediate" {
+				t.Errorf("unexpected subject %q or issuer %q", info.Subject, info.Issuer)
+			}
+			if len(info.SANs) != 2 || info.SANs[0] != "example.com" || info.SANs[1] != "www.example.com" {
+				t.Errorf("unexpected sans %v", info.SANs)
+			}
+			if info.FingerprintSHA256 != certificateFingerprint(leaf.cert) {
+				t.Errorf("unexpected fingerprint %q", info.FingerprintSHA256)
+			}
+		})
+	}
+}
+
+func TestSuppressEquivalentCertificate(t *testing.T) {
+	validUntil := time.Now().Add(24 * time.Hour)
+	root := newTestCertificate(t, "Test Root", nil, true, validUntil)
+	leaf := newTestCertificate(t, "example.com", root, false, validUntil)
+	other := newTestCertificate(t, "other.com", root, false, validUntil)
+
+	if !SuppressEquivalentCertificate("", leaf.pem, strings.ReplaceAll(leaf.pem, "\n", "\\n")+"\n\n", nil) {
+		t.Error("expected re-formatted certificate to be equivalent")
+	}
+	if SuppressEquivalentCertificate("", leaf.pem, other.pem, nil) {
+		t.Error("expected different certificates not to be equivalent")
+	}
+	if SuppressEquivalentCertificate("", leaf.pem, leaf.pem+root.pem, nil) {
+		t.Error("expected adding a chain certificate not to be equivalent")
+	}
+	if !SuppressEquivalentCertificate("", "not a certificate", "not a\ncertificate", nil) {
+		t.Error("expected unparsable content to be compared without whitespace")
+	}
+}
+
+func TestSuppressEquivalentPrivateKey(t *testing.T) {
+	validUntil := time.Now().Add(24 * time.Hour)
+	leaf := newTestCertificate(t, "example.com", nil, false, validUntil)
+	other := newTestCertificate(t, "other.com", nil, false, validUntil)
+
+	if !SuppressEquivalentPrivateKey("", leaf.keyPEM(t, true), leaf.keyPEM(t, false), nil) {
+		t.Error("expected PKCS#8 and SEC 1 encodings of the same key to be equivalent")
+	}
+	if SuppressEquivalentPrivateKey("", leaf.keyPEM(t, true), other.keyPEM(t, true), nil) {
+		t.Error("expected different keys not to be equivalent")
+	}
+}
+
+func TestParseCertificateTime(t *testing.T) {
+	want := time.Date(2025, 6, 1, 4, 0, 0, 0, time.UTC)
+	for _, value := range []string{"2025-06-01 12:00:00", "2025-06-01T12:00:00+08:00", "1748750400"} {
+		got, ok := ParseCertificateTime(value)
+		if !ok || !got.Equal(want) {
+			t.Errorf("ParseCertificateTime(%q) = %v, %v, want %v", value, got, ok, want)
+		}
+	}
+	if _, ok := ParseCertificateTime("not a time"); ok {
+		t.Errorf("ParseCertificateTime() expected failure")
+	}
+}
@@ -7,10 +7,16 @@ import (
 	"hash/crc32"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// APILocation is the time zone of times the EdgeNext APIs exchange without a zone
+// offset, China Standard Time (UTC+8)
+var APILocation = time.FixedZone("UTC+8", 8*60*60)
+
 func DataResourceIdsHash(ids []string) string {
 	var buf bytes.Buffer
 
@@ -43,6 +49,48 @@ func MergeStringBoolMaps(maps ...map[string]bool) map[string]bool {
 	return result
 }
 
+// SuggestClosest returns the candidate closest to value by edit distance, or an
+// empty string when no candidate is reasonably close. It is used to build
+// "did you mean" hints for unknown names.
+func SuggestClosest(value string, candidates []string) string {
+	best := ""
+	bestDistance := -1
+	for _, candidate := range candidates {
+		distance := levenshteinDistance(strings.ToLower(value), strings.ToLower(candidate))
+		if bestDistance < 0 || distance < bestDistance {
+			best = candidate
+			bestDistance = distance
+		}
+	}
+
+	// Only suggest when at most a third of the characters differ
+	if best == "" || bestDistance*3 > len(value)+1 {
+		return ""
+	}
+	return best
+}
+
+func levenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
 // MergeMap merges maps with overwrite: existing fields in dst are overwritten by src
 func MergeMap(dst, src map[string]interface{}) map[string]interface{} {
 	if dst == nil {
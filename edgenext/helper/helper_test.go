@@ -90,3 +90,23 @@ func TestWriteToFileNoOutput(t *testing.T) {
 		os.Remove("test_output.json") // Clean up if somehow created
 	}
 }
+
+func TestSuggestClosest(t *testing.T) {
+	candidates := []string{"http.request.uri.path", "http.request.method", "http.host"}
+
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "http.request.uri.pth", want: "http.request.uri.path"},
+		{value: "HTTP.HOST", want: "http.host"},
+		{value: "http.request.methd", want: "http.request.method"},
+		{value: "completely.unrelated", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := SuggestClosest(tt.value, candidates); got != tt.want {
+			t.Errorf("SuggestClosest(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
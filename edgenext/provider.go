@@ -25,6 +25,7 @@ import (
 	sdnsdomain "github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns/domain"
 	sdnsgroup "github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns/domain_group"
 	sdnsrecord "github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns/record"
+	sdnsrecordgroup "github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns/record_group"
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/ssl"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -75,6 +76,8 @@ func Provider() *schema.Provider {
 	sdnsGroupDataSources := sdnsgroup.DataSources()
 	sdnsRecordResources := sdnsrecord.Resources()
 	sdnsRecordDataSources := sdnsrecord.DataSources()
+	sdnsRecordGroupResources := sdnsrecordgroup.Resources()
+	sdnsRecordGroupDataSources := sdnsrecordgroup.DataSources()
 
 	// Build resources map
 	ResourcesMap := map[string]*schema.Resource{
@@ -82,8 +85,9 @@ func Provider() *schema.Provider {
 		"edgenext_cdn_domain": cdn.ResourceEdgenextCdnDomainConfig(),
 
 		// CDN cache prefetch and file purge resources
-		"edgenext_cdn_purge":    cdn.ResourceEdgenextCdnPurge(),
-		"edgenext_cdn_prefetch": cdn.ResourceEdgenextCdnPrefetch(),
+		"edgenext_cdn_purge":           cdn.ResourceEdgenextCdnPurge(),
+		"edgenext_cdn_purge_on_change": cdn.ResourceEdgenextCdnPurgeOnChange(),
+		"edgenext_cdn_prefetch":        cdn.ResourceEdgenextCdnPrefetch(),
 
 		// SSL certificate management resources
 		"edgenext_ssl_certificate": ssl.ResourceEdgenextSslCertificate(),
@@ -180,6 +184,9 @@ func Provider() *schema.Provider {
 	for k, v := range sdnsRecordResources {
 		ResourcesMap[k] = v
 	}
+	for k, v := range sdnsRecordGroupResources {
+		ResourcesMap[k] = v
+	}
 
 	// Build data sources map
 	DataSourcesMap := map[string]*schema.Resource{
@@ -196,8 +203,9 @@ func Provider() *schema.Provider {
 		"edgenext_cdn_prefetches": cdn.DataSourceEdgenextCdnPrefetches(),
 
 		// SSL certificate data sources
-		"edgenext_ssl_certificate":  ssl.DataSourceEdgenextSslCertificate(),
-		"edgenext_ssl_certificates": ssl.DataSourceEdgenextSslCertificates(),
+		"edgenext_ssl_certificate":       ssl.DataSourceEdgenextSslCertificate(),
+		"edgenext_ssl_certificates":      ssl.DataSourceEdgenextSslCertificates(),
+		"edgenext_certificates_expiring": ssl.DataSourceEdgenextCertificatesExpiring(),
 
 		// OSS bucket management data sources
 		"edgenext_oss_buckets": oss.DataSourceOSSBuckets(),
@@ -290,6 +298,9 @@ func Provider() *schema.Provider {
 	for k, v := range sdnsRecordDataSources {
 		DataSourcesMap[k] = v
 	}
+	for k, v := range sdnsRecordGroupDataSources {
+		DataSourcesMap[k] = v
+	}
 
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
@@ -0,0 +1,70 @@
+package cdn
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Purge and prefetch batching limits
+const (
+	// BatchMaxURLs is the maximum number of URLs accepted by a single purge or prefetch request
+	BatchMaxURLs = 500
+	// batchConcurrency is the maximum number of batch requests in flight
+	batchConcurrency = 4
+	// batchInterval is the minimum delay between starting two batch requests
+	batchInterval = 200 * time.Millisecond
+)
+
+// submitInBatches splits urls into chunks of BatchMaxURLs and submits them with bounded
+// concurrency and rate. It returns the task IDs of the successful chunks in chunk order and an
+// error naming every failed chunk.
+func submitInBatches(urls []string, submit func(batch []string) (string, error)) ([]string, error) {
+	chunks := make([][]string, 0, (len(urls)+BatchMaxURLs-1)/BatchMaxURLs)
+	for start := 0; start < len(urls); start += BatchMaxURLs {
+		end := start + BatchMaxURLs
+		if end > len(urls) {
+			end = len(urls)
+		}
+		chunks = append(chunks, urls[start:end])
+	}
+
+	taskIDs := make([]string, len(chunks))
+	errs := make([]error, len(chunks))
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, batchConcurrency)
+	ticker := time.NewTicker(batchInterval)
+	defer ticker.Stop()
+
+	for i, chunk := range chunks {
+		if i > 0 {
+			<-ticker.C
+		}
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func(i int, chunk []string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			taskIDs[i], errs[i] = submit(chunk)
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	succeeded := make([]string, 0, len(chunks))
+	var failed []string
+	for i, err := range errs {
+		if err != nil {
+			first := i*BatchMaxURLs + 1
+			failed = append(failed, fmt.Sprintf("chunk %d (URLs %d-%d, starting with %s): %v",
+				i+1, first, first+len(chunks[i])-1, chunks[i][0], err))
+			continue
+		}
+		succeeded = append(succeeded, taskIDs[i])
+	}
+	if len(failed) > 0 {
+		return succeeded, fmt.Errorf("%d of %d chunks failed:\n  %s", len(failed), len(chunks), strings.Join(failed, "\n  "))
+	}
+	return succeeded, nil
+}
@@ -0,0 +1,65 @@
+package cdn
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSubmitInBatches(t *testing.T) {
+	urls := make([]string, 1201)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://example.com/%d", i)
+	}
+
+	var mu sync.Mutex
+	var sizes []int
+	taskIDs, err := submitInBatches(urls, func(batch []string) (string, error) {
+		mu.Lock()
+		sizes = append(sizes, len(batch))
+		mu.Unlock()
+		return strings.TrimPrefix(batch[0], "https://example.com/"), nil
+	})
+	if err != nil {
+		t.Fatalf("submitInBatches() error = %v", err)
+	}
+	if want := []string{"0", "500", "1000"}; !reflect.DeepEqual(taskIDs, want) {
+		t.Errorf("submitInBatches() task IDs = %v, want %v", taskIDs, want)
+	}
+	total := 0
+	for _, size := range sizes {
+		if size > BatchMaxURLs {
+			t.Errorf("batch of %d URLs exceeds the limit of %d", size, BatchMaxURLs)
+		}
+		total += size
+	}
+	if total != len(urls) {
+		t.Errorf("submitted %d URLs, want %d", total, len(urls))
+	}
+}
+
+func TestSubmitInBatches_PartialFailure(t *testing.T) {
+	urls := make([]string, 1100)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://example.com/%d", i)
+	}
+
+	taskIDs, err := submitInBatches(urls, func(batch []string) (string, error) {
+		if batch[0] == "https://example.com/500" {
+			return "", errors.New("quota exceeded")
+		}
+		return batch[0], nil
+	})
+	if err == nil {
+		t.Fatalf("submitInBatches() expected error")
+	}
+	if !strings.Contains(err.Error(), "chunk 2 (URLs 501-1000") || !strings.Contains(err.Error(), "quota exceeded") {
+		t.Errorf("submitInBatches() error = %q, want it to name chunk 2", err.Error())
+	}
+	if len(taskIDs) != 2 {
+		t.Errorf("submitInBatches() returned %d task IDs, want 2", len(taskIDs))
+	}
+}
@@ -3,7 +3,6 @@ package cdn
 import (
 	"fmt"
 	"log"
-	"strconv"
 
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -21,7 +20,7 @@ func ResourceEdgenextCdnPrefetch() *schema.Resource {
 				Type:        schema.TypeList,
 				Required:    true,
 				ForceNew:    true, // Need to recreate task when urls list is updated
-				Description: "List of URLs to prefetch, maximum 500 URLs per request",
+				Description: "List of URLs to prefetch. Lists longer than 500 URLs, the per-request limit, are submitted in several batches",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -29,7 +28,15 @@ func ResourceEdgenextCdnPrefetch() *schema.Resource {
 			"task_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "Task ID for this submission",
+				Description: "Task ID for this submission, the ID of the first batch when the URLs were submitted in several batches",
+			},
+			"task_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Task IDs of all batches of this submission",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
 			},
 			"total": {
 				Type:        schema.TypeInt,
@@ -88,16 +95,20 @@ func resourcePrefetchCreate(d *schema.ResourceData, m interface{}) error {
 		urls = append(urls, url.(string))
 	}
 
-	// Call file prefetch API
-	response, err := service.FilePrefetch(urls)
+	// Submit in batches of at most BatchMaxURLs URLs
+	taskIDs, err := service.FilePrefetchBatched(urls)
+	if len(taskIDs) > 0 {
+		// Keep the submitted batches even if others failed, the resource is then tainted
+		d.SetId(taskIDs[0])
+		if setErr := d.Set("task_ids", taskIDs); setErr != nil {
+			log.Printf("[WARN] Failed to set task_ids: %v", setErr)
+		}
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create file prefetch task: %w", err)
 	}
 
-	// Set resource ID
-	d.SetId(response.Data.TaskID)
-
-	log.Printf("[INFO] File prefetch task created successfully: %s", response.Data.TaskID)
+	log.Printf("[INFO] File prefetch task created successfully: %v", taskIDs)
 	return resourcePrefetchRead(d, m)
 }
 
@@ -106,19 +117,16 @@ func resourcePrefetchRead(d *schema.ResourceData, m interface{}) error {
 	service := NewCdnService(client)
 
 	taskID := d.Id()
+	taskIDs := purgeTaskIDs(d)
 
-	log.Printf("[INFO] Reading file prefetch task: %s", taskID)
+	log.Printf("[INFO] Reading file prefetch task: %v", taskIDs)
 
-	// Query prefetch status by task ID
-	taskIDInt, err := strconv.Atoi(taskID)
-	if err != nil {
-		return fmt.Errorf("invalid task ID: %s", taskID)
-	}
-	response, err := service.QueryFilePrefetchByTaskID(taskIDInt)
+	// Query prefetch status of every batch
+	items, err := service.QueryFilePrefetchItems(taskIDs)
 	if err != nil {
 		return fmt.Errorf("failed to read file prefetch task: %w", err)
 	}
-	if len(response.Data.List) == 0 {
+	if len(items) == 0 {
 		log.Printf("[WARN] File prefetch task does not exist: %s", taskID)
 		d.SetId("")
 		return nil
@@ -129,11 +137,14 @@ func resourcePrefetchRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set("task_id", taskID); err != nil {
 		return fmt.Errorf("error setting task_id: %w", err)
 	}
-	if err := d.Set("total", response.Data.Total); err != nil {
+	if err := d.Set("task_ids", taskIDs); err != nil {
+		return fmt.Errorf("error setting task_ids: %w", err)
+	}
+	if err := d.Set("total", len(items)); err != nil {
 		return fmt.Errorf("error setting total: %w", err)
 	}
 	var list []map[string]interface{}
-	for _, elem := range response.Data.List {
+	for _, elem := range items {
 		elemMap := map[string]interface{}{
 			"id":            elem.ID,
 			"url":           elem.URL,
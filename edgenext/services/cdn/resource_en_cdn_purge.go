@@ -3,7 +3,6 @@ package cdn
 import (
 	"fmt"
 	"log"
-	"strconv"
 
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -21,7 +20,7 @@ func ResourceEdgenextCdnPurge() *schema.Resource {
 				Type:        schema.TypeList,
 				Required:    true,
 				ForceNew:    true, // Need to recreate task when urls list is updated
-				Description: "List of URLs/directories to purge, maximum 500 URLs per request",
+				Description: "List of URLs/directories to purge. Lists longer than 500 URLs, the per-request limit, are submitted in several batches",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -35,7 +34,15 @@ func ResourceEdgenextCdnPurge() *schema.Resource {
 			"task_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "Task ID for this submission",
+				Description: "Task ID for this submission, the ID of the first batch when the URLs were submitted in several batches",
+			},
+			"task_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Task IDs of all batches of this submission",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
 			},
 			"total": {
 				Type:        schema.TypeInt,
@@ -100,16 +107,20 @@ func resourcePurgeCreate(d *schema.ResourceData, m interface{}) error {
 		urls = append(urls, url.(string))
 	}
 
-	// Call cache refresh API
-	response, err := service.CacheRefresh(urls, purgeType)
+	// Submit in batches of at most BatchMaxURLs URLs
+	taskIDs, err := service.CacheRefreshBatched(urls, purgeType)
+	if len(taskIDs) > 0 {
+		// Keep the submitted batches even if others failed, the resource is then tainted
+		d.SetId(taskIDs[0])
+		if setErr := d.Set("task_ids", taskIDs); setErr != nil {
+			log.Printf("[WARN] Failed to set task_ids: %v", setErr)
+		}
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create cache purge task: %w", err)
 	}
 
-	// Set resource ID
-	d.SetId(response.Data.TaskID)
-
-	log.Printf("[INFO] Cache purge task created successfully: %s", response.Data.TaskID)
+	log.Printf("[INFO] Cache purge task created successfully: %v", taskIDs)
 	return resourcePurgeRead(d, m)
 }
 
@@ -118,19 +129,16 @@ func resourcePurgeRead(d *schema.ResourceData, m interface{}) error {
 	service := NewCdnService(client)
 
 	taskID := d.Id()
+	taskIDs := purgeTaskIDs(d)
 
-	log.Printf("[INFO] Reading cache purge task: %s", taskID)
+	log.Printf("[INFO] Reading cache purge task: %v", taskIDs)
 
-	// Query purge status by task ID
-	taskIDInt, err := strconv.Atoi(taskID)
-	if err != nil {
-		return fmt.Errorf("invalid task ID: %s", taskID)
-	}
-	response, err := service.QueryCacheRefreshByTaskID(taskIDInt)
+	// Query purge status of every batch
+	items, err := service.QueryCacheRefreshItems(taskIDs)
 	if err != nil {
 		return fmt.Errorf("failed to read cache purge task: %w", err)
 	}
-	if len(response.Data.List) == 0 {
+	if len(items) == 0 {
 		log.Printf("[WARN] Cache purge task does not exist: %s", taskID)
 		d.SetId("")
 		return nil
@@ -142,11 +150,14 @@ func resourcePurgeRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set("task_id", taskID); err != nil {
 		return fmt.Errorf("error setting task_id: %w", err)
 	}
-	if err := d.Set("total", response.Data.Total); err != nil {
+	if err := d.Set("task_ids", taskIDs); err != nil {
+		return fmt.Errorf("error setting task_ids: %w", err)
+	}
+	if err := d.Set("total", len(items)); err != nil {
 		return fmt.Errorf("error setting total: %w", err)
 	}
 	var list []map[string]interface{}
-	for _, elem := range response.Data.List {
+	for _, elem := range items {
 		elemMap := map[string]interface{}{
 			"id":            elem.ID,
 			"url":           elem.URL,
@@ -167,6 +178,21 @@ func resourcePurgeRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+// purgeTaskIDs returns the batch task IDs from state, falling back to the resource ID for
+// imported resources and resources created before batching
+func purgeTaskIDs(d *schema.ResourceData) []string {
+	taskIDs := make([]string, 0)
+	for _, v := range d.Get("task_ids").([]interface{}) {
+		if id, ok := v.(string); ok && id != "" {
+			taskIDs = append(taskIDs, id)
+		}
+	}
+	if len(taskIDs) == 0 {
+		taskIDs = append(taskIDs, d.Id())
+	}
+	return taskIDs
+}
+
 func resourcePurgeDelete(d *schema.ResourceData, m interface{}) error {
 	// API does not support deletion, can only no-op
 	log.Printf("[WARN] Cache purge task %s cannot be deleted (API limitation)", d.Id())
@@ -0,0 +1,297 @@
+package cdn
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceEdgenextCdnPurgeOnChange() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePurgeOnChangeCreate,
+		Read:   resourcePurgeOnChangeRead,
+		Update: resourcePurgeOnChangeUpdate,
+		Delete: resourcePurgeOnChangeDelete,
+
+		CustomizeDiff: resourcePurgeOnChangeCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"target": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "cdn",
+				ValidateFunc: validation.StringInSlice([]string{"cdn", "scdn"}, false),
+				Description:  "Where to purge: cdn (cache refresh) or scdn (cache clean task), default: cdn",
+			},
+			"object": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "Objects to track, e.g. from edgenext_oss_object",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bucket": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Bucket name",
+						},
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Object key",
+						},
+						"hash": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "ETag or content hash of the object, the object is purged when it changes",
+						},
+					},
+				},
+			},
+			"url_mapping": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "Mapping from bucket and key prefix to public URL prefix. An object matching several mappings is purged under each URL",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bucket": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Bucket name",
+						},
+						"key_prefix": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "Key prefix, stripped from the key before it is appended to url_prefix",
+						},
+						"url_prefix": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Public URL prefix, e.g. https://static.example.com/",
+						},
+					},
+				},
+			},
+			"purge_on_create": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to purge all objects when the resource is created, default: false",
+			},
+			"task_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "CDN purge task IDs of the last apply, empty for scdn",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"purged_urls": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "URLs purged by the last apply",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourcePurgeOnChangeCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	// Every apply records what it purged, so the computed results change with any update
+	if d.Id() != "" && d.HasChanges("object", "url_mapping", "target") {
+		for _, key := range []string{"task_ids", "purged_urls"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func resourcePurgeOnChangeCreate(d *schema.ResourceData, m interface{}) error {
+	objects := expandPurgeObjects(d.Get("object").([]interface{}))
+
+	var changed []purgeObject
+	if d.Get("purge_on_create").(bool) {
+		changed = objects
+	}
+
+	if err := resourcePurgeOnChangeSubmit(d, m, changed); err != nil {
+		return err
+	}
+
+	d.SetId(purgeOnChangeID(d.Get("url_mapping").([]interface{})))
+	return resourcePurgeOnChangeRead(d, m)
+}
+
+func resourcePurgeOnChangeRead(d *schema.ResourceData, m interface{}) error {
+	// Object hashes are only tracked in state, there is nothing to refresh
+	log.Printf("[DEBUG] Reading purge on change: %s", d.Id())
+	return nil
+}
+
+func resourcePurgeOnChangeUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := updatePurgeOnChange(d, func(changed []purgeObject) error {
+		return resourcePurgeOnChangeSubmit(d, m, changed)
+	}); err != nil {
+		return err
+	}
+	return resourcePurgeOnChangeRead(d, m)
+}
+
+// updatePurgeOnChange purges the objects whose hash changed. When the purge fails, even partly, the
+// prior state is kept so the new hashes are not recorded and the next apply purges the objects again.
+func updatePurgeOnChange(d *schema.ResourceData, submit func(changed []purgeObject) error) error {
+	oldObjects, newObjects := d.GetChange("object")
+	changed := changedPurgeObjects(
+		expandPurgeObjects(oldObjects.([]interface{})),
+		expandPurgeObjects(newObjects.([]interface{})),
+	)
+
+	if err := submit(changed); err != nil {
+		d.Partial(true)
+		return err
+	}
+	return nil
+}
+
+func resourcePurgeOnChangeDelete(d *schema.ResourceData, m interface{}) error {
+	// Purges cannot be undone, only remove from state
+	log.Printf("[INFO] Removing purge on change %s from state", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// resourcePurgeOnChangeSubmit purges the URLs of the changed objects in batches of BatchMaxURLs
+func resourcePurgeOnChangeSubmit(d *schema.ResourceData, m interface{}, changed []purgeObject) error {
+	client := m.(*connectivity.EdgeNextClient)
+	urls := purgeURLs(changed, d.Get("url_mapping").([]interface{}))
+	target := d.Get("target").(string)
+
+	taskIDs := make([]string, 0)
+	if len(urls) > 0 {
+		log.Printf("[INFO] Purging %d changed object URL(s) on %s", len(urls), target)
+	}
+	switch target {
+	case "scdn":
+		service := scdn.NewScdnService(client)
+		if _, err := submitInBatches(urls, func(batch []string) (string, error) {
+			_, err := service.SaveCacheCleanTask(scdn.CacheCleanSaveRequest{Specialurl: batch})
+			return "", err
+		}); err != nil {
+			return fmt.Errorf("failed to create SCDN cache clean task: %w", err)
+		}
+	default:
+		service := NewCdnService(client)
+		ids, err := service.CacheRefreshBatched(urls, RefreshTypeURL)
+		taskIDs = append(taskIDs, ids...)
+		if err != nil {
+			return fmt.Errorf("failed to create cache purge task: %w", err)
+		}
+	}
+
+	if err := d.Set("task_ids", taskIDs); err != nil {
+		return fmt.Errorf("error setting task_ids: %w", err)
+	}
+	if err := d.Set("purged_urls", urls); err != nil {
+		return fmt.Errorf("error setting purged_urls: %w", err)
+	}
+	return nil
+}
+
+// purgeObject is a tracked object
+type purgeObject struct {
+	Bucket string
+	Key    string
+	Hash   string
+}
+
+func expandPurgeObjects(list []interface{}) []purgeObject {
+	objects := make([]purgeObject, 0, len(list))
+	for _, item := range list {
+		if item == nil {
+			continue
+		}
+		raw := item.(map[string]interface{})
+		objects = append(objects, purgeObject{
+			Bucket: raw["bucket"].(string),
+			Key:    raw["key"].(string),
+			Hash:   raw["hash"].(string),
+		})
+	}
+	return objects
+}
+
+// changedPurgeObjects returns the objects whose hash changed, and removed objects
+func changedPurgeObjects(oldObjects, newObjects []purgeObject) []purgeObject {
+	oldHashes := make(map[string]string, len(oldObjects))
+	for _, object := range oldObjects {
+		oldHashes[object.Bucket+"/"+object.Key] = object.Hash
+	}
+
+	changed := make([]purgeObject, 0)
+	current := make(map[string]bool, len(newObjects))
+	for _, object := range newObjects {
+		id := object.Bucket + "/" + object.Key
+		current[id] = true
+		if hash, ok := oldHashes[id]; ok && hash != object.Hash {
+			changed = append(changed, object)
+		}
+	}
+	for _, object := range oldObjects {
+		if !current[object.Bucket+"/"+object.Key] {
+			changed = append(changed, object)
+		}
+	}
+	return changed
+}
+
+// purgeURLs maps objects to public URLs using every matching url_mapping, without duplicates
+func purgeURLs(objects []purgeObject, mappings []interface{}) []string {
+	seen := make(map[string]bool)
+	urls := make([]string, 0)
+	for _, object := range objects {
+		for _, item := range mappings {
+			if item == nil {
+				continue
+			}
+			mapping := item.(map[string]interface{})
+			keyPrefix := mapping["key_prefix"].(string)
+			if mapping["bucket"].(string) != object.Bucket || !strings.HasPrefix(object.Key, keyPrefix) {
+				continue
+			}
+			url := mapping["url_prefix"].(string) + strings.TrimPrefix(object.Key, keyPrefix)
+			if !seen[url] {
+				seen[url] = true
+				urls = append(urls, url)
+			}
+		}
+	}
+	return urls
+}
+
+// purgeOnChangeID derives a stable ID from the URL mappings
+func purgeOnChangeID(mappings []interface{}) string {
+	parts := make([]string, 0, len(mappings))
+	for _, item := range mappings {
+		if item == nil {
+			continue
+		}
+		mapping := item.(map[string]interface{})
+		parts = append(parts, fmt.Sprintf("%s/%s=%s", mapping["bucket"], mapping["key_prefix"], mapping["url_prefix"]))
+	}
+	sort.Strings(parts)
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return "purge-on-change-" + hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,106 @@
+package cdn
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestChangedPurgeObjects(t *testing.T) {
+	oldObjects := []purgeObject{
+		{Bucket: "b", Key: "index.html", Hash: "1"},
+		{Bucket: "b", Key: "app.js", Hash: "2"},
+		{Bucket: "b", Key: "old.css", Hash: "3"},
+	}
+	newObjects := []purgeObject{
+		{Bucket: "b", Key: "index.html", Hash: "1"},
+		{Bucket: "b", Key: "app.js", Hash: "4"},
+		{Bucket: "b", Key: "new.css", Hash: "5"},
+	}
+
+	got := changedPurgeObjects(oldObjects, newObjects)
+	want := []purgeObject{
+		{Bucket: "b", Key: "app.js", Hash: "4"},
+		{Bucket: "b", Key: "old.css", Hash: "3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("changedPurgeObjects() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPurgeURLs(t *testing.T) {
+	mappings := []interface{}{
+		map[string]interface{}{"bucket": "b", "key_prefix": "site/", "url_prefix": "https://www.example.com/"},
+		map[string]interface{}{"bucket": "b", "key_prefix": "", "url_prefix": "https://static.example.com/"},
+		map[string]interface{}{"bucket": "other", "key_prefix": "", "url_prefix": "https://other.example.com/"},
+	}
+	objects := []purgeObject{
+		{Bucket: "b", Key: "site/index.html"},
+		{Bucket: "b", Key: "img/logo.png"},
+	}
+
+	got := purgeURLs(objects, mappings)
+	want := []string{
+		"https://www.example.com/index.html",
+		"https://static.example.com/site/index.html",
+		"https://static.example.com/img/logo.png",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("purgeURLs() = %v, want %v", got, want)
+	}
+}
+
+func TestUpdatePurgeOnChange_FailureKeepsPriorHashes(t *testing.T) {
+	mapping := map[string]interface{}{"bucket": "b", "key_prefix": "", "url_prefix": "https://static.example.com/"}
+	objects := func(hash string) map[string]interface{} {
+		return map[string]interface{}{
+			"object":      []interface{}{map[string]interface{}{"bucket": "b", "key": "app.js", "hash": hash}},
+			"url_mapping": []interface{}{mapping},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		submitErr error
+		wantHash  string
+	}{
+		{name: "purge succeeded", submitErr: nil, wantHash: "2"},
+		{name: "purge failed", submitErr: errors.New("refresh quota exceeded"), wantHash: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var purged []purgeObject
+			r := ResourceEdgenextCdnPurgeOnChange()
+			r.Update = func(d *schema.ResourceData, _ interface{}) error {
+				return updatePurgeOnChange(d, func(changed []purgeObject) error {
+					purged = changed
+					return tt.submitErr
+				})
+			}
+			ctx := context.Background()
+
+			prior := schema.TestResourceDataRaw(t, r.Schema, objects("1"))
+			prior.SetId("purge-on-change-test")
+			diff, err := r.Diff(ctx, prior.State(), terraform.NewResourceConfigRaw(objects("2")), nil)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+
+			state, diags := r.Apply(ctx, prior.State(), diff, nil)
+			if diags.HasError() != (tt.submitErr != nil) {
+				t.Fatalf("Apply() diagnostics = %v, want error %v", diags, tt.submitErr)
+			}
+			if want := []purgeObject{{Bucket: "b", Key: "app.js", Hash: "2"}}; !reflect.DeepEqual(purged, want) {
+				t.Errorf("purged objects = %+v, want %+v", purged, want)
+			}
+			if got := state.Attributes["object.0.hash"]; got != tt.wantHash {
+				t.Errorf("state object.0.hash = %q, want %q", got, tt.wantHash)
+			}
+		})
+	}
+}
@@ -668,12 +668,12 @@ func (c *CdnService) QueryCacheRefresh(req CacheRefreshQueryRequest) (*CacheRefr
 		if req.URL != "" {
 			query["url"] = req.URL
 		}
-		if req.PageNumber != "" {
-			query["page_number"] = req.PageNumber
-		}
-		if req.PageSize != "" {
-			query["page_size"] = req.PageSize
-		}
+	}
+	if req.PageNumber != "" {
+		query["page_number"] = req.PageNumber
+	}
+	if req.PageSize != "" {
+		query["page_size"] = req.PageSize
 	}
 
 	var response CacheRefreshQueryResponse
@@ -741,6 +741,46 @@ func NewCacheRefreshRequest(urls []string, refreshType string) CacheRefreshReque
 	}
 }
 
+// CacheRefreshBatched submits a cache refresh of any number of URLs in batches of BatchMaxURLs
+// and returns the task IDs of the successful batches in order
+func (c *CdnService) CacheRefreshBatched(urls []string, refreshType string) ([]string, error) {
+	return submitInBatches(urls, func(batch []string) (string, error) {
+		response, err := c.CacheRefresh(batch, refreshType)
+		if err != nil {
+			return "", err
+		}
+		return response.Data.TaskID, nil
+	})
+}
+
+// QueryCacheRefreshItems returns all items of the given cache refresh tasks. Tasks that no
+// longer exist are skipped.
+func (c *CdnService) QueryCacheRefreshItems(taskIDs []string) ([]CacheRefreshQueryItem, error) {
+	items := make([]CacheRefreshQueryItem, 0)
+	for _, taskID := range taskIDs {
+		id, err := strconv.Atoi(taskID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid task ID: %s", taskID)
+		}
+		for page, seen := 1, 0; ; page++ {
+			response, err := c.QueryCacheRefresh(CacheRefreshQueryRequest{
+				TaskID:     id,
+				PageNumber: strconv.Itoa(page),
+				PageSize:   strconv.Itoa(BatchMaxURLs),
+			})
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, response.Data.List...)
+			seen += len(response.Data.List)
+			if len(response.Data.List) == 0 || seen >= response.Data.Total {
+				break
+			}
+		}
+	}
+	return items, nil
+}
+
 // File prefetch related structs and methods
 
 // FilePrefetchRequest file prefetch request
@@ -852,12 +892,12 @@ func (c *CdnService) QueryFilePrefetch(req FilePrefetchQueryRequest) (*FilePrefe
 		if req.URL != "" {
 			query["url"] = req.URL
 		}
-		if req.PageNumber != "" {
-			query["page_number"] = req.PageNumber
-		}
-		if req.PageSize != "" {
-			query["page_size"] = req.PageSize
-		}
+	}
+	if req.PageNumber != "" {
+		query["page_number"] = req.PageNumber
+	}
+	if req.PageSize != "" {
+		query["page_size"] = req.PageSize
 	}
 
 	var response FilePrefetchQueryResponse
@@ -913,3 +953,43 @@ func (item *FilePrefetchQueryItem) IsProcessing() bool {
 func (item *FilePrefetchQueryItem) IsFailed() bool {
 	return item.Status == PrefetchStatusFailed
 }
+
+// FilePrefetchBatched submits a file prefetch of any number of URLs in batches of BatchMaxURLs
+// and returns the task IDs of the successful batches in order
+func (c *CdnService) FilePrefetchBatched(urls []string) ([]string, error) {
+	return submitInBatches(urls, func(batch []string) (string, error) {
+		response, err := c.FilePrefetch(batch)
+		if err != nil {
+			return "", err
+		}
+		return response.Data.TaskID, nil
+	})
+}
+
+// QueryFilePrefetchItems returns all items of the given file prefetch tasks. Tasks that no
+// longer exist are skipped.
+func (c *CdnService) QueryFilePrefetchItems(taskIDs []string) ([]FilePrefetchQueryItem, error) {
+	items := make([]FilePrefetchQueryItem, 0)
+	for _, taskID := range taskIDs {
+		id, err := strconv.Atoi(taskID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid task ID: %s", taskID)
+		}
+		for page, seen := 1, 0; ; page++ {
+			response, err := c.QueryFilePrefetch(FilePrefetchQueryRequest{
+				TaskID:     id,
+				PageNumber: strconv.Itoa(page),
+				PageSize:   strconv.Itoa(BatchMaxURLs),
+			})
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, response.Data.List...)
+			seen += len(response.Data.List)
+			if len(response.Data.List) == 0 || seen >= response.Data.Total {
+				break
+			}
+		}
+	}
+	return items, nil
+}
@@ -10,6 +10,7 @@ func Resources() map[string]*schema.Resource {
 		"edgenext_scdn_cache_rule":        ResourceEdgenextScdnCacheRule(),
 		"edgenext_scdn_cache_rule_status": ResourceEdgenextScdnCacheRuleStatus(),
 		"edgenext_scdn_cache_rules_sort":  ResourceEdgenextScdnCacheRulesSort(),
+		"edgenext_scdn_cache_rules":       ResourceEdgenextScdnCacheRules(),
 	}
 }
 
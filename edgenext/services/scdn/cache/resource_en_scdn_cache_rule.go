@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
@@ -9,6 +10,7 @@ import (
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // ResourceEdgenextScdnCacheRule returns the SCDN cache rule resource
@@ -23,6 +25,8 @@ func ResourceEdgenextScdnCacheRule() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: resourceScdnCacheRuleCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"business_id": {
 				Type:        schema.TypeInt,
@@ -54,199 +58,34 @@ func ResourceEdgenextScdnCacheRule() *schema.Resource {
 				Description: "Rule name",
 			},
 			"expr": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Wirefilter rule. Empty string means 'allow all'. If not set (null), keeps existing value.",
-			},
-			"remark": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Rule remark",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ConflictsWith:    []string{"match"},
+				ValidateFunc:     validateWirefilterExpr,
+				DiffSuppressFunc: suppressEquivalentWirefilter,
+				Description:      "Wirefilter rule. Empty string means 'allow all'. If not set (null), keeps existing value. The expression syntax is checked at plan time, and fields other than `http.request.uri.path` and `http.request.postfix` produce a warning. When `match` is set, this is the compiled expression.",
 			},
-			"conf": {
-				Type:        schema.TypeList,
-				Required:    true,
-				MaxItems:    1,
-				Description: "Cache configuration",
+			"match": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"expr"},
+				Description:   "Structured request match compiled to a wirefilter `expr`. Groups are ANDed together. Conditions inside `all` must all match, at least one condition inside `any` must match, and no condition inside `not` may match.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"nocache": {
-							Type:        schema.TypeBool,
-							Required:    true,
-							Description: "Cache eligibility (true: bypass cache, false: cache)",
-						},
-						"cache_rule": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							Computed:    true,
-							MaxItems:    1,
-							Description: "Edge TTL cache configuration",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"cachetime": {
-										Type:        schema.TypeInt,
-										Required:    true,
-										Description: "Cache time",
-									},
-									"ignore_cache_time": {
-										Type:        schema.TypeBool,
-										Optional:    true,
-										Description: "Ignore source cache time",
-									},
-									"ignore_nocache_header": {
-										Type:        schema.TypeBool,
-										Optional:    true,
-										Description: "Ignore no-cache header",
-									},
-									"no_cache_control_op": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "No cache control operation",
-									},
-									"action": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Description: "Cache action: 'default', 'nocache', 'cachetime', or 'force'",
-									},
-								},
-							},
-						},
-						"browser_cache_rule": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							Computed:    true,
-							MaxItems:    1,
-							Description: "Browser cache configuration",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"cachetime": {
-										Type:        schema.TypeInt,
-										Required:    true,
-										Description: "Cache time",
-									},
-									"ignore_cache_time": {
-										Type:        schema.TypeBool,
-										Required:    true,
-										Description: "Ignore source cache time (cache-control)",
-									},
-									"nocache": {
-										Type:        schema.TypeBool,
-										Required:    true,
-										Description: "Whether to cache",
-									},
-								},
-							},
-						},
-						"cache_errstatus": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							Computed:    true,
-							Description: "Status code cache configuration",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"cachetime": {
-										Type:        schema.TypeInt,
-										Required:    true,
-										Description: "Status code cache time",
-									},
-									"err_status": {
-										Type:        schema.TypeList,
-										Required:    true,
-										Description: "Status code array",
-										Elem: &schema.Schema{
-											Type: schema.TypeInt,
-										},
-									},
-								},
-							},
-						},
-						"cache_url_rewrite": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							Computed:    true,
-							MaxItems:    1,
-							Description: "Custom cache key configuration",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"sort_args": {
-										Type:        schema.TypeBool,
-										Required:    true,
-										Description: "Parameter sorting",
-									},
-									"ignore_case": {
-										Type:        schema.TypeBool,
-										Required:    true,
-										Description: "Ignore case",
-									},
-									"queries": {
-										Type:        schema.TypeList,
-										Optional:    true,
-										MaxItems:    1,
-										Description: "Query string processing",
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"args_method": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "Action: 'SAVE', 'DEL', 'IGNORE', or 'CUT'",
-												},
-												"items": {
-													Type:        schema.TypeList,
-													Required:    true,
-													Description: "Parameter keys",
-													Elem: &schema.Schema{
-														Type: schema.TypeString,
-													},
-												},
-											},
-										},
-									},
-									"cookies": {
-										Type:        schema.TypeList,
-										Optional:    true,
-										MaxItems:    1,
-										Description: "Cookie processing",
-										Elem: &schema.Resource{
-											Schema: map[string]*schema.Schema{
-												"args_method": {
-													Type:        schema.TypeString,
-													Required:    true,
-													Description: "Action: 'SAVE', 'DEL', 'IGNORE', or 'CUT'",
-												},
-												"items": {
-													Type:        schema.TypeList,
-													Required:    true,
-													Description: "Cookie keys",
-													Elem: &schema.Schema{
-														Type: schema.TypeString,
-													},
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-						"cache_share": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							Computed:    true,
-							MaxItems:    1,
-							Description: "Cache sharing configuration",
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"scheme": {
-										Type:        schema.TypeString,
-										Optional:    true,
-										Computed:    true,
-										Description: "HTTP/HTTPS cache sharing method: '', 'http' or 'https'",
-									},
-								},
-							},
-						},
+						"all": cacheRuleMatchConditionsSchema("Conditions that must all match"),
+						"any": cacheRuleMatchConditionsSchema("Conditions of which at least one must match"),
+						"not": cacheRuleMatchConditionsSchema("Conditions of which none may match"),
 					},
 				},
 			},
+			"remark": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Rule remark",
+			},
+			"conf": cacheRuleConfSchema(),
 			// Computed fields
 			"id": {
 				Type:        schema.TypeString,
@@ -500,6 +339,9 @@ func resourceScdnCacheRuleRead(d *schema.ResourceData, m interface{}) error {
 	if err := d.Set("expr", foundRule.Expr); err != nil {
 		log.Printf("[WARN] Failed to set expr: %v", err)
 	}
+	if err := setCacheRuleMatchFromExpr(d, foundRule.Expr); err != nil {
+		log.Printf("[WARN] Failed to set match: %v", err)
+	}
 	if err := d.Set("status", foundRule.Status); err != nil {
 		log.Printf("[WARN] Failed to set status: %v", err)
 	}
@@ -759,8 +601,199 @@ func resourceScdnCacheRuleDelete(d *schema.ResourceData, m interface{}) error {
 
 // Helper functions
 
+// cacheRuleConfSchema returns the schema of the cache rule conf block
+func cacheRuleConfSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Required:    true,
+		MaxItems:    1,
+		Description: "Cache configuration",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"nocache": {
+					Type:        schema.TypeBool,
+					Required:    true,
+					Description: "Cache eligibility (true: bypass cache, false: cache)",
+				},
+				"cache_rule": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Computed:    true,
+					MaxItems:    1,
+					Description: "Edge TTL cache configuration",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"cachetime": {
+								Type:        schema.TypeInt,
+								Required:    true,
+								Description: "Cache time",
+							},
+							"ignore_cache_time": {
+								Type:        schema.TypeBool,
+								Optional:    true,
+								Description: "Ignore source cache time",
+							},
+							"ignore_nocache_header": {
+								Type:        schema.TypeBool,
+								Optional:    true,
+								Description: "Ignore no-cache header",
+							},
+							"no_cache_control_op": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "No cache control operation",
+							},
+							"action": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "Cache action: 'default', 'nocache', 'cachetime', or 'force'",
+							},
+						},
+					},
+				},
+				"browser_cache_rule": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Computed:    true,
+					MaxItems:    1,
+					Description: "Browser cache configuration",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"cachetime": {
+								Type:        schema.TypeInt,
+								Required:    true,
+								Description: "Cache time",
+							},
+							"ignore_cache_time": {
+								Type:        schema.TypeBool,
+								Required:    true,
+								Description: "Ignore source cache time (cache-control)",
+							},
+							"nocache": {
+								Type:        schema.TypeBool,
+								Required:    true,
+								Description: "Whether to cache",
+							},
+						},
+					},
+				},
+				"cache_errstatus": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Computed:    true,
+					Description: "Status code cache configuration",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"cachetime": {
+								Type:        schema.TypeInt,
+								Required:    true,
+								Description: "Status code cache time",
+							},
+							"err_status": {
+								Type:        schema.TypeList,
+								Required:    true,
+								Description: "Status code array",
+								Elem: &schema.Schema{
+									Type: schema.TypeInt,
+								},
+							},
+						},
+					},
+				},
+				"cache_url_rewrite": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Computed:    true,
+					MaxItems:    1,
+					Description: "Custom cache key configuration",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"sort_args": {
+								Type:        schema.TypeBool,
+								Required:    true,
+								Description: "Parameter sorting",
+							},
+							"ignore_case": {
+								Type:        schema.TypeBool,
+								Required:    true,
+								Description: "Ignore case",
+							},
+							"queries": {
+								Type:        schema.TypeList,
+								Optional:    true,
+								MaxItems:    1,
+								Description: "Query string processing",
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"args_method": {
+											Type:        schema.TypeString,
+											Required:    true,
+											Description: "Action: 'SAVE', 'DEL', 'IGNORE', or 'CUT'",
+										},
+										"items": {
+											Type:        schema.TypeList,
+											Required:    true,
+											Description: "Parameter keys",
+											Elem: &schema.Schema{
+												Type: schema.TypeString,
+											},
+										},
+									},
+								},
+							},
+							"cookies": {
+								Type:        schema.TypeList,
+								Optional:    true,
+								MaxItems:    1,
+								Description: "Cookie processing",
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"args_method": {
+											Type:        schema.TypeString,
+											Required:    true,
+											Description: "Action: 'SAVE', 'DEL', 'IGNORE', or 'CUT'",
+										},
+										"items": {
+											Type:        schema.TypeList,
+											Required:    true,
+											Description: "Cookie keys",
+											Elem: &schema.Schema{
+												Type: schema.TypeString,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"cache_share": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Computed:    true,
+					MaxItems:    1,
+					Description: "Cache sharing configuration",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"scheme": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Computed:    true,
+								Description: "HTTP/HTTPS cache sharing method: '', 'http' or 'https'",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func buildCacheRuleConfFromSchema(d *schema.ResourceData) (*scdn.CacheRuleConf, error) {
-	confList := d.Get("conf").([]interface{})
+	return buildCacheRuleConfFromList(d.Get("conf").([]interface{}))
+}
+
+func buildCacheRuleConfFromList(confList []interface{}) (*scdn.CacheRuleConf, error) {
 	if len(confList) == 0 {
 		return nil, fmt.Errorf("conf is required")
 	}
@@ -974,3 +1007,259 @@ func buildCacheRuleConfToSchema(conf *scdn.CacheRuleConf) map[string]interface{}
 
 	return confMap
 }
+
+// cacheRuleMatchConditionsSchema returns the schema of one match condition group
+func cacheRuleMatchConditionsSchema(description string) *schema.Schema {
+	keyMatchSchema := func(field, nameDescription string) *schema.Resource {
+		return &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: warnUndocumentedWirefilterField(field),
+					Description:  nameDescription,
+				},
+				"operator": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "eq",
+					ValidateFunc: validation.StringInSlice(scdn.WirefilterKeyOperators, false),
+					Description:  "Comparison operator: 'eq', 'ne', 'contains' or 'matches' (regular expression)",
+				},
+				"value": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Value to compare with",
+				},
+			},
+		}
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"uri_prefix": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "URI path prefixes, e.g. '/static/'. Each prefix must start with '/'",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"uri_path": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Exact URI paths",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"extension": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "File extensions without the leading dot, e.g. 'css'",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"method": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "HTTP methods, e.g. 'GET'",
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: warnUndocumentedWirefilterField("http.request.method"),
+					},
+				},
+				"host": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Request hosts",
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: warnUndocumentedWirefilterField("http.host"),
+					},
+				},
+				"header": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Request header comparisons",
+					Elem:        keyMatchSchema("http.request.headers", "Header name, case insensitive"),
+				},
+				"query_arg": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Query argument comparisons",
+					Elem:        keyMatchSchema("http.request.uri.args", "Query argument name"),
+				},
+			},
+		},
+	}
+}
+
+// validateWirefilterExpr checks the syntax of a wirefilter expression with the
+// local parser and warns about undocumented fields
+func validateWirefilterExpr(v interface{}, k string) ([]string, []error) {
+	if err := scdn.ValidateWirefilter(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %w", k, err)}
+	}
+	var warnings []string
+	for _, warning := range scdn.WirefilterFieldWarnings(v.(string)) {
+		warnings = append(warnings, fmt.Sprintf("%s: %s", k, warning))
+	}
+	return warnings, nil
+}
+
+// warnUndocumentedWirefilterField warns that a match condition compiles to a
+// field that is not documented for SCDN rules, like validateWirefilterExpr does for expr
+func warnUndocumentedWirefilterField(field string) schema.SchemaValidateFunc {
+	return func(_ interface{}, k string) ([]string, []error) {
+		if warning := scdn.WirefilterFieldWarning(field); warning != "" {
+			return []string{fmt.Sprintf("%s: %s", k, warning)}, nil
+		}
+		return nil, nil
+	}
+}
+
+// suppressEquivalentWirefilter suppresses diffs between expressions that only differ in formatting
+func suppressEquivalentWirefilter(k, old, new string, d *schema.ResourceData) bool {
+	return scdn.WirefilterEquivalent(old, new)
+}
+
+// resourceScdnCacheRuleCustomizeDiff compiles match into expr at plan time
+func resourceScdnCacheRuleCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("match") {
+		return d.SetNewComputed("expr")
+	}
+
+	match := expandCacheRuleMatch(d.Get("match").([]interface{}))
+	if match == nil {
+		// Removing match without setting expr resets the rule to 'allow all'
+		if d.Id() != "" && d.HasChange("match") && d.GetRawConfig().GetAttr("expr").IsNull() {
+			return d.SetNew("expr", "")
+		}
+		return nil
+	}
+
+	expr, err := match.Compile()
+	if err != nil {
+		return fmt.Errorf("invalid match: %w", err)
+	}
+	if old := d.Get("expr").(string); scdn.WirefilterEquivalent(old, expr) {
+		return nil
+	}
+	return d.SetNew("expr", expr)
+}
+
+// expandCacheRuleMatch converts the match block into a wirefilter match
+func expandCacheRuleMatch(raw []interface{}) *scdn.WirefilterMatch {
+	if len(raw) == 0 || raw[0] == nil {
+		return nil
+	}
+	matchMap := raw[0].(map[string]interface{})
+
+	match := &scdn.WirefilterMatch{
+		All: expandCacheRuleMatchConditions(matchMap["all"].([]interface{})),
+		Any: expandCacheRuleMatchConditions(matchMap["any"].([]interface{})),
+		Not: expandCacheRuleMatchConditions(matchMap["not"].([]interface{})),
+	}
+	if match.All == nil && match.Any == nil && match.Not == nil {
+		return nil
+	}
+	return match
+}
+
+func expandCacheRuleMatchConditions(raw []interface{}) *scdn.WirefilterConditions {
+	if len(raw) == 0 || raw[0] == nil {
+		return nil
+	}
+	condMap := raw[0].(map[string]interface{})
+
+	expandStrings := func(key string) []string {
+		var values []string
+		for _, v := range condMap[key].([]interface{}) {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	expandKeyMatches := func(key string) []scdn.WirefilterKeyMatch {
+		var values []scdn.WirefilterKeyMatch
+		for _, v := range condMap[key].([]interface{}) {
+			if item, ok := v.(map[string]interface{}); ok {
+				values = append(values, scdn.WirefilterKeyMatch{
+					Name:     item["name"].(string),
+					Operator: item["operator"].(string),
+					Value:    item["value"].(string),
+				})
+			}
+		}
+		return values
+	}
+
+	return &scdn.WirefilterConditions{
+		URIPrefix: expandStrings("uri_prefix"),
+		URIPath:   expandStrings("uri_path"),
+		Extension: expandStrings("extension"),
+		Method:    expandStrings("method"),
+		Host:      expandStrings("host"),
+		Header:    expandKeyMatches("header"),
+		QueryArg:  expandKeyMatches("query_arg"),
+	}
+}
+
+func flattenCacheRuleMatch(match *scdn.WirefilterMatch) []interface{} {
+	matchMap := map[string]interface{}{
+		"all": flattenCacheRuleMatchConditions(match.All),
+		"any": flattenCacheRuleMatchConditions(match.Any),
+		"not": flattenCacheRuleMatchConditions(match.Not),
+	}
+	return []interface{}{matchMap}
+}
+
+func flattenCacheRuleMatchConditions(cond *scdn.WirefilterConditions) []interface{} {
+	if cond == nil {
+		return []interface{}{}
+	}
+
+	flattenKeyMatches := func(values []scdn.WirefilterKeyMatch) []interface{} {
+		items := make([]interface{}, 0, len(values))
+		for _, v := range values {
+			items = append(items, map[string]interface{}{
+				"name":     v.Name,
+				"operator": v.Operator,
+				"value":    v.Value,
+			})
+		}
+		return items
+	}
+
+	condMap := map[string]interface{}{
+		"uri_prefix": cond.URIPrefix,
+		"uri_path":   cond.URIPath,
+		"extension":  cond.Extension,
+		"method":     cond.Method,
+		"host":       cond.Host,
+		"header":     flattenKeyMatches(cond.Header),
+		"query_arg":  flattenKeyMatches(cond.QueryArg),
+	}
+	return []interface{}{condMap}
+}
+
+// setCacheRuleMatchFromExpr round-trips the API expression into match when match is in use.
+// The configured match is kept if it still compiles to an equivalent expression.
+func setCacheRuleMatchFromExpr(d *schema.ResourceData, expr string) error {
+	current := expandCacheRuleMatch(d.Get("match").([]interface{}))
+	if current == nil {
+		return nil
+	}
+	if compiled, err := current.Compile(); err == nil && scdn.WirefilterEquivalent(compiled, expr) {
+		return nil
+	}
+
+	match, err := scdn.DecompileWirefilter(expr)
+	if err != nil {
+		log.Printf("[WARN] Cache rule expr cannot be represented as match, clearing match: %v", err)
+		return d.Set("match", nil)
+	}
+	return d.Set("match", flattenCacheRuleMatch(match))
+}
@@ -0,0 +1,372 @@
+package cache
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ResourceEdgenextScdnCacheRules returns the SCDN cache rules resource.
+// It manages the complete, ordered cache rule list of one template or domain.
+func ResourceEdgenextScdnCacheRules() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceScdnCacheRulesCreate,
+		Read:   resourceScdnCacheRulesRead,
+		Update: resourceScdnCacheRulesUpdate,
+		Delete: resourceScdnCacheRulesDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"business_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Business ID (template ID for 'tpl' type, domain ID for 'domain' type)",
+			},
+			"business_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Business type: 'tpl' (template) or 'domain'",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Ordered cache rules. Rules are matched to existing rules by name, so names must be unique. Rules not listed here are deleted, and the list order is applied as the rule priority.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Rule name, unique within the list",
+						},
+						"expr": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     validateWirefilterExpr,
+							DiffSuppressFunc: suppressEquivalentWirefilter,
+							Description:      "Wirefilter rule. Empty string means 'allow all'.",
+						},
+						"remark": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Rule remark",
+						},
+						"conf": cacheRuleConfSchema(),
+						"rule_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Rule ID",
+						},
+						"status": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Status (1: enabled, 2: disabled)",
+						},
+					},
+				},
+			},
+			// Computed fields
+			"rule_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Rule IDs in priority order",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+	}
+}
+
+func resourceScdnCacheRulesCreate(d *schema.ResourceData, m interface{}) error {
+	businessID := d.Get("business_id").(int)
+	businessType := d.Get("business_type").(string)
+
+	d.SetId(fmt.Sprintf("%d-%s", businessID, businessType))
+
+	if err := resourceScdnCacheRulesApply(d, m); err != nil {
+		return err
+	}
+
+	return resourceScdnCacheRulesRead(d, m)
+}
+
+func resourceScdnCacheRulesRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	businessID, businessType, err := parseScdnCacheRulesID(d)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading SCDN cache rules: business_id=%d, business_type=%s", businessID, businessType)
+	rules, err := listScdnCacheRules(service, businessID, businessType)
+	if err != nil {
+		return fmt.Errorf("failed to read cache rules: %w", err)
+	}
+
+	// Every rule of the business is reported, so rules created outside Terraform show up as drift
+	ruleList := make([]interface{}, 0, len(rules))
+	ruleIDs := make([]int, 0, len(rules))
+	for _, rule := range rules {
+		ruleMap := map[string]interface{}{
+			"name":    rule.Name,
+			"expr":    rule.Expr,
+			"remark":  rule.Remark,
+			"conf":    []interface{}{},
+			"rule_id": rule.ID,
+			"status":  rule.Status,
+		}
+		if rule.Conf != nil {
+			ruleMap["conf"] = []interface{}{buildCacheRuleConfToSchema(rule.Conf)}
+		}
+		ruleList = append(ruleList, ruleMap)
+		ruleIDs = append(ruleIDs, rule.ID)
+	}
+
+	d.SetId(fmt.Sprintf("%d-%s", businessID, businessType))
+
+	if err := d.Set("business_id", businessID); err != nil {
+		log.Printf("[WARN] Failed to set business_id: %v", err)
+	}
+	if err := d.Set("business_type", businessType); err != nil {
+		log.Printf("[WARN] Failed to set business_type: %v", err)
+	}
+	if err := d.Set("rule", ruleList); err != nil {
+		return fmt.Errorf("error setting rule: %w", err)
+	}
+	if err := d.Set("rule_ids", ruleIDs); err != nil {
+		log.Printf("[WARN] Failed to set rule_ids: %v", err)
+	}
+
+	log.Printf("[INFO] SCDN cache rules read successfully: rule_ids=%v", ruleIDs)
+	return nil
+}
+
+func resourceScdnCacheRulesUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("rule") {
+		if err := resourceScdnCacheRulesApply(d, m); err != nil {
+			return err
+		}
+	}
+
+	return resourceScdnCacheRulesRead(d, m)
+}
+
+func resourceScdnCacheRulesDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	businessID, businessType, err := parseScdnCacheRulesID(d)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int, 0)
+	for _, v := range d.Get("rule_ids").([]interface{}) {
+		if id, ok := v.(int); ok && id > 0 {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) > 0 {
+		req := scdn.CacheRuleDeleteRequest{
+			BusinessID:   businessID,
+			BusinessType: businessType,
+			IDs:          ids,
+		}
+
+		log.Printf("[INFO] Deleting SCDN cache rules: business_id=%d, business_type=%s, ids=%v", businessID, businessType, ids)
+		if _, err := service.DeleteCacheRule(req); err != nil {
+			return fmt.Errorf("failed to delete cache rules: %w", err)
+		}
+	}
+
+	d.SetId("")
+	log.Printf("[INFO] SCDN cache rules deleted successfully")
+	return nil
+}
+
+// resourceScdnCacheRulesApply makes the API rule list match the configuration:
+// unlisted rules are deleted, listed rules are created or updated, then the list is sorted once.
+func resourceScdnCacheRulesApply(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	businessID := d.Get("business_id").(int)
+	businessType := d.Get("business_type").(string)
+
+	desired := d.Get("rule").([]interface{})
+	seen := make(map[string]bool, len(desired))
+	for _, item := range desired {
+		name := item.(map[string]interface{})["name"].(string)
+		if seen[name] {
+			return fmt.Errorf("duplicate cache rule name %q, rule names must be unique", name)
+		}
+		seen[name] = true
+	}
+
+	existing, err := listScdnCacheRules(service, businessID, businessType)
+	if err != nil {
+		return fmt.Errorf("failed to read cache rules: %w", err)
+	}
+	existingByName := make(map[string]scdn.CacheRuleInfo, len(existing))
+	var deleteIDs []int
+	for _, rule := range existing {
+		if _, ok := existingByName[rule.Name]; ok || !seen[rule.Name] {
+			deleteIDs = append(deleteIDs, rule.ID)
+			continue
+		}
+		existingByName[rule.Name] = rule
+	}
+
+	// Previous configuration of each rule, used to skip rules that did not change
+	oldRaw, _ := d.GetChange("rule")
+	oldByName := make(map[string]map[string]interface{})
+	for _, item := range oldRaw.([]interface{}) {
+		if itemMap, ok := item.(map[string]interface{}); ok {
+			oldByName[itemMap["name"].(string)] = itemMap
+		}
+	}
+
+	if len(deleteIDs) > 0 {
+		log.Printf("[INFO] Deleting SCDN cache rules not in configuration: ids=%v", deleteIDs)
+		_, err := service.DeleteCacheRule(scdn.CacheRuleDeleteRequest{
+			BusinessID:   businessID,
+			BusinessType: businessType,
+			IDs:          deleteIDs,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete cache rules: %w", err)
+		}
+	}
+
+	ids := make([]int, 0, len(desired))
+	for i, item := range desired {
+		itemMap := item.(map[string]interface{})
+		name := itemMap["name"].(string)
+
+		conf, err := buildCacheRuleConfFromList(itemMap["conf"].([]interface{}))
+		if err != nil {
+			return fmt.Errorf("failed to build conf of rule %d (%s): %w", i, name, err)
+		}
+
+		current, ok := existingByName[name]
+		if !ok {
+			req := scdn.CacheRuleCreateRequest{
+				BusinessID:   businessID,
+				BusinessType: businessType,
+				Name:         name,
+				Expr:         itemMap["expr"].(string),
+				Remark:       itemMap["remark"].(string),
+				Conf:         conf,
+			}
+
+			log.Printf("[INFO] Creating SCDN cache rule: name=%s", name)
+			response, err := service.CreateCacheRule(req)
+			if err != nil {
+				return fmt.Errorf("failed to create cache rule %q: %w", name, err)
+			}
+			ids = append(ids, response.Data.ID)
+			continue
+		}
+
+		ids = append(ids, current.ID)
+		if oldItem, ok := oldByName[name]; ok && oldItem["rule_id"] == current.ID && cacheRuleItemsEqual(oldItem, itemMap) {
+			continue
+		}
+
+		req := scdn.CacheRuleUpdateConfigRequest{
+			ID:           current.ID,
+			Name:         name,
+			Remark:       itemMap["remark"].(string),
+			Expr:         itemMap["expr"].(string),
+			Conf:         conf,
+			BusinessID:   businessID,
+			BusinessType: businessType,
+		}
+
+		log.Printf("[INFO] Updating SCDN cache rule: rule_id=%d, name=%s", current.ID, name)
+		if _, err := service.UpdateCacheRuleConfig(req); err != nil {
+			return fmt.Errorf("failed to update cache rule %q: %w", name, err)
+		}
+	}
+
+	if len(ids) > 0 {
+		req := scdn.CacheRuleSortRequest{
+			BusinessID:   businessID,
+			BusinessType: businessType,
+			IDs:          ids,
+		}
+
+		log.Printf("[INFO] Sorting SCDN cache rules: business_id=%d, business_type=%s, ids=%v", businessID, businessType, ids)
+		if _, err := service.SortCacheRules(req); err != nil {
+			return fmt.Errorf("failed to sort cache rules: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// cacheRuleItemsEqual reports whether two rule blocks have the same configuration
+func cacheRuleItemsEqual(a, b map[string]interface{}) bool {
+	if a["name"] != b["name"] || a["remark"] != b["remark"] {
+		return false
+	}
+	if !scdn.WirefilterEquivalent(a["expr"].(string), b["expr"].(string)) {
+		return false
+	}
+	return reflect.DeepEqual(a["conf"], b["conf"])
+}
+
+// listScdnCacheRules returns all cache rules of a business in priority order
+func listScdnCacheRules(service *scdn.ScdnService, businessID int, businessType string) ([]scdn.CacheRuleInfo, error) {
+	const pageSize = 100
+
+	var rules []scdn.CacheRuleInfo
+	for page := 1; ; page++ {
+		response, err := service.GetCacheRules(scdn.CacheRuleGetRulesRequest{
+			BusinessID:   businessID,
+			BusinessType: businessType,
+			Page:         page,
+			PageSize:     pageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, response.Data.List...)
+		if len(response.Data.List) < pageSize || len(rules) >= response.Data.Total {
+			return rules, nil
+		}
+	}
+}
+
+// parseScdnCacheRulesID parses the business_id-business_type resource ID
+func parseScdnCacheRulesID(d *schema.ResourceData) (int, string, error) {
+	parts := strings.SplitN(d.Id(), "-", 2)
+	if len(parts) == 2 {
+		businessID, err := strconv.Atoi(parts[0])
+		if err == nil && parts[1] != "" {
+			return businessID, parts[1], nil
+		}
+	}
+
+	businessID := d.Get("business_id").(int)
+	businessType := d.Get("business_type").(string)
+	if businessID == 0 || businessType == "" {
+		return 0, "", fmt.Errorf("invalid resource ID %q, expected business_id-business_type", d.Id())
+	}
+	return businessID, businessType, nil
+}
@@ -0,0 +1,128 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// cacheTaskSubmitSlack is subtracted from the submit time when looking for the tasks of a
+// submission, to allow for clock skew between the provider and the API
+const cacheTaskSubmitSlack = time.Minute
+
+// cacheTaskMaxListPages bounds the task list pages scanned per poll
+const cacheTaskMaxListPages = 10
+
+// Per-URL status values stored in url_status
+const (
+	cacheTaskURLStatusProcessing = "processing"
+	cacheTaskURLStatusSuccess    = "success"
+	cacheTaskURLStatusFailed     = "failed"
+)
+
+// cacheTaskURLStatusSchema returns the computed url_status schema shared by clean and preheat tasks
+func cacheTaskURLStatusSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Per-URL processing result, only populated when wait_for_completion is true",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"url": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "URL, directory or domain",
+				},
+				"status": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Status: processing, success, failed, or the result returned by the API when it is none of these",
+				},
+				"message": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Message returned by the API",
+				},
+			},
+		},
+	}
+}
+
+// waitForCacheTask polls until every URL returned by poll has finished. A nil result from poll
+// means the task is not visible yet.
+func waitForCacheTask(ctx context.Context, kind string, poll func() ([]map[string]interface{}, error)) ([]map[string]interface{}, error) {
+	var statuses []map[string]interface{}
+	for {
+		current, err := poll()
+		if err != nil {
+			log.Printf("[WARN] Failed to query SCDN cache %s task status: %v", kind, err)
+		} else if current != nil {
+			statuses = current
+			processing := 0
+			for _, status := range statuses {
+				if status["status"] == cacheTaskURLStatusProcessing {
+					processing++
+				}
+			}
+			if processing == 0 {
+				return statuses, nil
+			}
+			log.Printf("[DEBUG] Waiting for SCDN cache %s task: %d of %d URLs still processing", kind, processing, len(statuses))
+		}
+
+		select {
+		case <-ctx.Done():
+			return statuses, fmt.Errorf("timeout while waiting for SCDN cache %s task to complete", kind)
+		case <-time.After(10 * time.Second):
+		}
+	}
+}
+
+// setCacheTaskURLStatus stores statuses in url_status and returns an error listing failed URLs
+func setCacheTaskURLStatus(d *schema.ResourceData, kind string, statuses []map[string]interface{}) error {
+	if err := d.Set("url_status", statuses); err != nil {
+		return fmt.Errorf("error setting url_status: %w", err)
+	}
+
+	var failed []string
+	for _, status := range statuses {
+		if status["status"] == cacheTaskURLStatusFailed {
+			failed = append(failed, fmt.Sprintf("%s (%s)", status["url"], status["message"]))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("SCDN cache %s failed for %d URL(s):\n  %s", kind, len(failed), strings.Join(failed, "\n  "))
+	}
+	return nil
+}
+
+// pendingCacheTaskURLStatuses returns a status for every submitted URL, marking URLs without a
+// task yet as processing
+func pendingCacheTaskURLStatuses(urls []string, found map[string]map[string]interface{}) []map[string]interface{} {
+	statuses := make([]map[string]interface{}, 0, len(urls))
+	for _, url := range urls {
+		if status, ok := found[url]; ok {
+			statuses = append(statuses, status)
+			continue
+		}
+		statuses = append(statuses, map[string]interface{}{
+			"url":     url,
+			"status":  cacheTaskURLStatusProcessing,
+			"message": "not queued yet",
+		})
+	}
+	return statuses
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
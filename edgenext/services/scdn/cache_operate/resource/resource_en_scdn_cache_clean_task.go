@@ -1,10 +1,16 @@
 package resource
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/helper"
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -17,6 +23,11 @@ func ResourceEdgenextScdnCacheCleanTask() *schema.Resource {
 		Update: resourceScdnCacheCleanTaskUpdate,
 		Delete: resourceScdnCacheCleanTaskDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"group_id": {
 				Type:        schema.TypeInt,
@@ -57,12 +68,27 @@ func ResourceEdgenextScdnCacheCleanTask() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to wait until every URL has been cleaned, bounded by the create/update timeout. URLs that fail during processing are reported as an error",
+			},
 			// Computed fields
 			"id": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "The ID of the cache clean task (generated timestamp)",
 			},
+			"task_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the clean tasks created by the submission, matched on the submitted URLs. Only populated when wait_for_completion is true",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"url_status": cacheTaskURLStatusSchema(),
 		},
 	}
 }
@@ -110,6 +136,11 @@ func resourceScdnCacheCleanTaskCreate(d *schema.ResourceData, m interface{}) err
 		return fmt.Errorf("at least one of wholesite, specialurl, or specialdir must be provided")
 	}
 
+	// Tasks created by this submission are matched on the submitted URLs among the tasks
+	// created since the submit time, the API does not return their IDs
+	waitForCompletion := d.Get("wait_for_completion").(bool)
+	submittedAt := time.Now().Add(-cacheTaskSubmitSlack)
+
 	log.Printf("[INFO] Creating SCDN cache clean task")
 	response, err := service.SaveCacheCleanTask(req)
 	if err != nil {
@@ -124,6 +155,27 @@ func resourceScdnCacheCleanTaskCreate(d *schema.ResourceData, m interface{}) err
 	d.SetId("cache-clean-task")
 
 	log.Printf("[INFO] SCDN cache clean task created successfully: %s", d.Id())
+
+	if err := d.Set("task_ids", []int{}); err != nil {
+		log.Printf("[WARN] Failed to set task_ids: %v", err)
+	}
+	if err := d.Set("url_status", []interface{}{}); err != nil {
+		log.Printf("[WARN] Failed to set url_status: %v", err)
+	}
+	if waitForCompletion {
+		timeout := d.Timeout(schema.TimeoutCreate)
+		if !d.IsNewResource() {
+			timeout = d.Timeout(schema.TimeoutUpdate)
+		}
+		urls := make([]string, 0, len(req.Wholesite)+len(req.Specialurl)+len(req.Specialdir))
+		urls = append(urls, req.Wholesite...)
+		urls = append(urls, req.Specialurl...)
+		urls = append(urls, req.Specialdir...)
+		if err := waitForScdnCacheCleanTask(d, service, submittedAt, urls, timeout); err != nil {
+			return err
+		}
+	}
+
 	return resourceScdnCacheCleanTaskRead(d, m)
 }
 
@@ -135,6 +187,11 @@ func resourceScdnCacheCleanTaskRead(d *schema.ResourceData, m interface{}) error
 }
 
 func resourceScdnCacheCleanTaskUpdate(d *schema.ResourceData, m interface{}) error {
+	// Toggling wait_for_completion alone does not submit the clean again
+	if !d.HasChangesExcept("wait_for_completion") {
+		return resourceScdnCacheCleanTaskRead(d, m)
+	}
+
 	// For cache clean tasks, update means creating a new task
 	return resourceScdnCacheCleanTaskCreate(d, m)
 }
@@ -145,3 +202,130 @@ func resourceScdnCacheCleanTaskDelete(d *schema.ResourceData, m interface{}) err
 	d.SetId("")
 	return nil
 }
+
+// waitForScdnCacheCleanTask waits until every submitted URL, directory or domain has finished
+// and stores the per-URL results. Each one is attributed to the newest task created since
+// submittedAt that lists it
+func waitForScdnCacheCleanTask(d *schema.ResourceData, service *scdn.ScdnService, submittedAt time.Time, urls []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var taskIDs []int
+	statuses, err := waitForCacheTask(ctx, "clean", func() ([]map[string]interface{}, error) {
+		tasks, err := listScdnCacheCleanTasksSince(service, submittedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		taskIDs = taskIDs[:0]
+		found := make(map[string]map[string]interface{})
+		for _, task := range tasks {
+			details, err := listScdnCacheCleanTaskDetails(service, task.TaskID)
+			if err != nil {
+				return nil, err
+			}
+			matched := false
+			for _, detail := range details {
+				url := detail.URL
+				if url == "" {
+					url = detail.Directory
+				}
+				if url == "" {
+					url = detail.Subdomain
+				}
+				if _, ok := found[url]; ok || !containsString(urls, url) {
+					continue
+				}
+				found[url] = map[string]interface{}{
+					"url":     url,
+					"status":  normalizeScdnCacheCleanResult(detail.Result),
+					"message": detail.Message,
+				}
+				matched = true
+			}
+			if matched {
+				taskIDs = append(taskIDs, task.TaskID)
+			}
+		}
+		if len(found) == 0 {
+			return nil, nil
+		}
+		return pendingCacheTaskURLStatuses(urls, found), nil
+	})
+
+	if setErr := d.Set("task_ids", taskIDs); setErr != nil {
+		log.Printf("[WARN] Failed to set task_ids: %v", setErr)
+	}
+	if err != nil {
+		if setErr := d.Set("url_status", statuses); setErr != nil {
+			log.Printf("[WARN] Failed to set url_status: %v", setErr)
+		}
+		return err
+	}
+	return setCacheTaskURLStatus(d, "clean", statuses)
+}
+
+// listScdnCacheCleanTasksSince returns the cache clean tasks created at or after since, newest first
+func listScdnCacheCleanTasksSince(service *scdn.ScdnService, since time.Time) ([]scdn.CacheCleanTaskInfo, error) {
+	req := scdn.CacheCleanTaskListRequest{
+		PerPage:   50,
+		StartTime: since.In(helper.APILocation).Format("2006-01-02 15:04:05"),
+	}
+
+	var tasks []scdn.CacheCleanTaskInfo
+	for page := 1; page <= cacheTaskMaxListPages; page++ {
+		req.Page = page
+		response, err := service.GetCacheCleanTaskList(req)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range response.Data.List {
+			if createdAt, err := time.Parse(time.RFC3339, task.CreatedAt); err == nil && createdAt.Before(since) {
+				continue
+			}
+			tasks = append(tasks, task)
+		}
+		if len(response.Data.List) < req.PerPage {
+			break
+		}
+	}
+
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].TaskID > tasks[j].TaskID })
+	return tasks, nil
+}
+
+// listScdnCacheCleanTaskDetails returns all detail entries of a cache clean task
+func listScdnCacheCleanTaskDetails(service *scdn.ScdnService, taskID int) ([]scdn.CacheCleanTaskDetailInfo, error) {
+	var details []scdn.CacheCleanTaskDetailInfo
+	for page := 1; ; page++ {
+		response, err := service.GetCacheCleanTaskDetail(scdn.CacheCleanTaskDetailRequest{
+			TaskID:  taskID,
+			Page:    page,
+			PerPage: 100,
+		})
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, response.Data.List...)
+		total, _ := strconv.Atoi(response.Data.Total.String())
+		if len(response.Data.List) == 0 || len(details) >= total {
+			return details, nil
+		}
+	}
+}
+
+// normalizeScdnCacheCleanResult maps the documented detail results (成功/失败/执行中) to a
+// url_status status. An empty result means the URL has not been processed yet, other
+// results are passed through unchanged.
+func normalizeScdnCacheCleanResult(result string) string {
+	switch strings.TrimSpace(result) {
+	case "成功":
+		return cacheTaskURLStatusSuccess
+	case "失败":
+		return cacheTaskURLStatusFailed
+	case "执行中", "":
+		return cacheTaskURLStatusProcessing
+	default:
+		return result
+	}
+}
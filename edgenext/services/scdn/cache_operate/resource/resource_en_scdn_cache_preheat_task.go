@@ -1,10 +1,14 @@
 package resource
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strconv"
+	"time"
 
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/helper"
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -17,6 +21,11 @@ func ResourceEdgenextScdnCachePreheatTask() *schema.Resource {
 		Update: resourceScdnCachePreheatTaskUpdate,
 		Delete: resourceScdnCachePreheatTaskDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"group_id": {
 				Type:        schema.TypeInt,
@@ -41,6 +50,12 @@ func ResourceEdgenextScdnCachePreheatTask() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to wait until every URL has been preheated, bounded by the create/update timeout. URLs that fail during processing are reported as an error",
+			},
 			// Computed fields
 			"id": {
 				Type:        schema.TypeString,
@@ -55,6 +70,7 @@ func ResourceEdgenextScdnCachePreheatTask() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"url_status": cacheTaskURLStatusSchema(),
 		},
 	}
 }
@@ -88,6 +104,11 @@ func resourceScdnCachePreheatTaskCreate(d *schema.ResourceData, m interface{}) e
 		return fmt.Errorf("preheat_url is required")
 	}
 
+	// Preheat entries created by this submission are matched on URL among the entries created
+	// since the submit time, the API does not return their IDs
+	waitForCompletion := d.Get("wait_for_completion").(bool)
+	submittedAt := time.Now().Add(-cacheTaskSubmitSlack)
+
 	log.Printf("[INFO] Creating SCDN cache preheat task")
 	response, err := service.SaveCachePreheatTask(req)
 	if err != nil {
@@ -110,6 +131,28 @@ func resourceScdnCachePreheatTaskCreate(d *schema.ResourceData, m interface{}) e
 	}
 
 	log.Printf("[INFO] SCDN cache preheat task created successfully: %s", d.Id())
+
+	if err := d.Set("url_status", []interface{}{}); err != nil {
+		log.Printf("[WARN] Failed to set url_status: %v", err)
+	}
+	if waitForCompletion {
+		// URLs rejected on submit are reported in error_url and never get a task
+		urls := make([]string, 0, len(req.PreheatURL))
+		for _, url := range req.PreheatURL {
+			if !containsString(errorURLs, url) {
+				urls = append(urls, url)
+			}
+		}
+
+		timeout := d.Timeout(schema.TimeoutCreate)
+		if !d.IsNewResource() {
+			timeout = d.Timeout(schema.TimeoutUpdate)
+		}
+		if err := waitForScdnCachePreheatTask(d, service, submittedAt, urls, timeout); err != nil {
+			return err
+		}
+	}
+
 	return resourceScdnCachePreheatTaskRead(d, m)
 }
 
@@ -121,6 +164,11 @@ func resourceScdnCachePreheatTaskRead(d *schema.ResourceData, m interface{}) err
 }
 
 func resourceScdnCachePreheatTaskUpdate(d *schema.ResourceData, m interface{}) error {
+	// Toggling wait_for_completion alone does not submit the preheat again
+	if !d.HasChangesExcept("wait_for_completion") {
+		return resourceScdnCachePreheatTaskRead(d, m)
+	}
+
 	// For cache preheat tasks, update means creating a new task
 	return resourceScdnCachePreheatTaskCreate(d, m)
 }
@@ -131,3 +179,66 @@ func resourceScdnCachePreheatTaskDelete(d *schema.ResourceData, m interface{}) e
 	d.SetId("")
 	return nil
 }
+
+// waitForScdnCachePreheatTask waits for the newest entry created since submittedAt of every URL
+// and stores the per-URL results. Entries are looked up with one paged task list per poll
+func waitForScdnCachePreheatTask(d *schema.ResourceData, service *scdn.ScdnService, submittedAt time.Time, urls []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	statuses, err := waitForCacheTask(ctx, "preheat", func() ([]map[string]interface{}, error) {
+		latest := make(map[string]scdn.CachePreheatTaskInfo)
+		statusMap := make(map[string]string)
+		req := scdn.CachePreheatTaskListRequest{PerPage: 100}
+		for page := 1; page <= cacheTaskMaxListPages; page++ {
+			req.Page = page
+			response, err := service.GetCachePreheatTaskList(req)
+			if err != nil {
+				return nil, err
+			}
+			for key, value := range response.Data.StatusMap {
+				statusMap[key] = value
+			}
+
+			for _, task := range response.Data.List {
+				createdAt, err := time.ParseInLocation("2006-01-02 15:04:05", task.TimeCreate, helper.APILocation)
+				if err == nil && createdAt.Before(submittedAt) {
+					continue
+				}
+				if !containsString(urls, task.URL) {
+					continue
+				}
+				if current, ok := latest[task.URL]; !ok || task.ID > current.ID {
+					latest[task.URL] = task
+				}
+			}
+			if len(latest) == len(urls) || len(response.Data.List) < req.PerPage {
+				break
+			}
+		}
+
+		found := make(map[string]map[string]interface{}, len(latest))
+		for url, task := range latest {
+			status := map[string]interface{}{
+				"url":     url,
+				"status":  cacheTaskURLStatusProcessing,
+				"message": statusMap[strconv.Itoa(task.Status)],
+			}
+			switch task.Status {
+			case 3:
+				status["status"] = cacheTaskURLStatusSuccess
+			case 4:
+				status["status"] = cacheTaskURLStatusFailed
+			}
+			found[url] = status
+		}
+		return pendingCacheTaskURLStatuses(urls, found), nil
+	})
+	if err != nil {
+		if setErr := d.Set("url_status", statuses); setErr != nil {
+			log.Printf("[WARN] Failed to set url_status: %v", setErr)
+		}
+		return err
+	}
+	return setCacheTaskURLStatus(d, "preheat", statuses)
+}
@@ -7,8 +7,12 @@ import (
 // Resources returns all certificate-related resources
 func Resources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"edgenext_scdn_certificate":       ResourceEdgenextScdnCertificate(),
-		"edgenext_scdn_certificate_apply": ResourceEdgenextScdnCertificateApply(),
+		"edgenext_scdn_certificate":                 ResourceEdgenextScdnCertificate(),
+		"edgenext_scdn_certificate_apply":           ResourceEdgenextScdnCertificateApply(),
+		"edgenext_scdn_certificate_upload":          ResourceEdgenextScdnCertificateUpload(),
+		"edgenext_scdn_certificate_info":            ResourceEdgenextScdnCertificateInfo(),
+		"edgenext_scdn_certificate_batch_binding":   ResourceEdgenextScdnCertificateBatchBinding(),
+		"edgenext_scdn_certificate_batch_operation": ResourceEdgenextScdnCertificateBatchOperation(),
 	}
 }
 
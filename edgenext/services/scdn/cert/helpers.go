@@ -0,0 +1,54 @@
+package cert
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// scdnDomainPageSize is the page size used to walk the SCDN domain list
+const scdnDomainPageSize = 100
+
+// listScdnDomains returns every SCDN domain, walking all pages of the domain list
+func listScdnDomains(service *scdn.ScdnService) ([]scdn.DomainInfo, error) {
+	domains := make([]scdn.DomainInfo, 0)
+	for page := 1; ; page++ {
+		response, err := service.ListDomains(scdn.DomainListRequest{
+			Page:     page,
+			PageSize: scdnDomainPageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list SCDN domains: %w", err)
+		}
+		domains = append(domains, response.Data.List...)
+		if len(response.Data.List) < scdnDomainPageSize || page*scdnDomainPageSize >= response.Data.Total {
+			return domains, nil
+		}
+	}
+}
+
+// listScdnDomainCertificates returns the certificate ID bound to every SCDN domain, keyed by domain ID.
+// Domains without a certificate map to 0.
+func listScdnDomainCertificates(service *scdn.ScdnService) (map[int]int, error) {
+	domains, err := listScdnDomains(service)
+	if err != nil {
+		return nil, err
+	}
+	certificates := make(map[int]int, len(domains))
+	for _, domain := range domains {
+		certificates[domain.ID] = domain.CAID
+	}
+	return certificates, nil
+}
+
+// expandIntSet returns the sorted elements of a set of integers
+func expandIntSet(set *schema.Set) []int {
+	values := make([]int, 0, set.Len())
+	for _, v := range set.List() {
+		values = append(values, v.(int))
+	}
+	sort.Ints(values)
+	return values
+}
@@ -1,11 +1,14 @@
 package cert
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/helper"
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -22,6 +25,8 @@ func ResourceEdgenextScdnCertificate() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: resourceScdnCertificateCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"certificate_id": {
 				Type:        schema.TypeString,
@@ -34,16 +39,18 @@ func ResourceEdgenextScdnCertificate() *schema.Resource {
 				Description: "The certificate name",
 			},
 			"ca_cert": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Sensitive:   true,
-				Description: "The certificate public key (PEM format). Required for creation, optional for updates.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				Description:      "The certificate public key (PEM format), leaf certificate first followed by its chain. Required for creation, optional for updates.",
+				DiffSuppressFunc: helper.SuppressEquivalentCertificate,
 			},
 			"ca_key": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Sensitive:   true,
-				Description: "The certificate private key (PEM format). Required for creation, optional for updates.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				Description:      "The certificate private key (PEM format). Required for creation, optional for updates.",
+				DiffSuppressFunc: helper.SuppressEquivalentPrivateKey,
 			},
 			"product_flag": {
 				Type:        schema.TypeString,
@@ -119,6 +126,29 @@ func ResourceEdgenextScdnCertificate() *schema.Resource {
 				Computed:    true,
 				Description: "The certificate domain type: 1-single domain, 2-multiple domains, 3-wildcard domain",
 			},
+			"subject": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The subject of the leaf certificate, parsed locally from ca_cert",
+			},
+			"sans": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The subject alternative names of the leaf certificate, parsed locally from ca_cert",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"not_after": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The expiry time of the leaf certificate in RFC 3339 format, parsed locally from ca_cert",
+			},
+			"fingerprint_sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA-256 fingerprint of the leaf certificate, parsed locally from ca_cert",
+			},
 			"created_at": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -317,6 +347,9 @@ func resourceScdnCertificateRead(d *schema.ResourceData, m interface{}) error {
 
 	// Note: ca_cert and ca_key are not returned by the API for security reasons
 	// They are only set during creation/update
+	if caCert, ok := d.GetOk("ca_cert"); ok {
+		setScdnCertificateInfo(d, caCert.(string))
+	}
 
 	log.Printf("[INFO] SCDN certificate read successfully: %s", d.Id())
 	return nil
@@ -414,3 +447,63 @@ func resourceScdnCertificateDelete(d *schema.ResourceData, m interface{}) error
 	log.Printf("[INFO] SCDN certificate deleted successfully: %s", certID)
 	return nil
 }
+
+// scdnCertificateInfoAttributes are the attributes derived locally from ca_cert, issuer is
+// reported by the API instead
+var scdnCertificateInfoAttributes = []string{"subject", "sans", "not_after", "fingerprint_sha256"}
+
+// resourceScdnCertificateCustomizeDiff validates ca_cert and ca_key at plan time and derives the
+// certificate attributes locally
+func resourceScdnCertificateCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	raw := d.GetRawConfig()
+	caCert := raw.GetAttr("ca_cert")
+	caKey := raw.GetAttr("ca_key")
+	if !caCert.IsKnown() || !caKey.IsKnown() {
+		for _, k := range scdnCertificateInfoAttributes {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if caCert.IsNull() {
+		return nil
+	}
+
+	// An unchanged certificate is not validated again, so an existing one passing its expiry does not block plans
+	if d.Id() != "" && !d.HasChanges("ca_cert", "ca_key") {
+		return nil
+	}
+
+	key := ""
+	if !caKey.IsNull() {
+		key = caKey.AsString()
+	}
+	info, err := helper.ValidateCertificate(caCert.AsString(), key, time.Now())
+	if err != nil {
+		return fmt.Errorf("invalid SCDN certificate: %w", err)
+	}
+
+	attributes := info.Attributes()
+	for _, k := range scdnCertificateInfoAttributes {
+		if err := d.SetNew(k, attributes[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setScdnCertificateInfo derives the certificate attributes from ca_cert
+func setScdnCertificateInfo(d *schema.ResourceData, caCert string) {
+	chain, err := helper.ParseCertificateChain(caCert)
+	if err != nil {
+		log.Printf("[WARN] Failed to parse SCDN certificate %s: %v", d.Id(), err)
+		return
+	}
+	attributes := helper.GetCertificateInfo(chain).Attributes()
+	for _, k := range scdnCertificateInfoAttributes {
+		if err := d.Set(k, attributes[k]); err != nil {
+			log.Printf("[WARN] Failed to set %s: %v", k, err)
+		}
+	}
+}
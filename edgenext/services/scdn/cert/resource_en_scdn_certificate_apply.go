@@ -1,13 +1,19 @@
 package cert
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/helper"
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // ResourceEdgenextScdnCertificateApply returns the SCDN certificate apply resource
@@ -15,12 +21,19 @@ func ResourceEdgenextScdnCertificateApply() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceScdnCertificateApplyCreate,
 		Read:   resourceScdnCertificateApplyRead,
+		Update: resourceScdnCertificateApplyUpdate,
 		Delete: resourceScdnCertificateApplyDelete,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: resourceScdnCertificateApplyCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:        schema.TypeList,
@@ -31,6 +44,13 @@ func ResourceEdgenextScdnCertificateApply() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"renew_before_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Renew the certificate when it expires within this many days, 0 disables renewal. Renewal applies for a new certificate, waits until it is issued, rebinds the bound domains and then deletes the old certificate",
+			},
 			// Computed fields
 			"id": {
 				Type:        schema.TypeString,
@@ -53,6 +73,27 @@ func ResourceEdgenextScdnCertificateApply() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"issuer_expiry_time": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The mapping of ca_id to certificate expiry time",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"apply_status": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The mapping of ca_id to application status: 1-applying, 2-issued, 3-review failed, 4-uploaded",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"renewal_of": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Comma separated IDs of the certificates replaced by a renewal that has not finished moving their domains, empty otherwise. The next apply resumes the renewal",
+			},
 		},
 	}
 }
@@ -61,6 +102,159 @@ func resourceScdnCertificateApplyCreate(d *schema.ResourceData, m interface{}) e
 	client := m.(*connectivity.EdgeNextClient)
 	service := scdn.NewScdnService(client)
 
+	response, err := applyScdnCertificate(service, d)
+	if err != nil {
+		return err
+	}
+	setScdnCertificateApplyResult(d, response)
+
+	log.Printf("[INFO] SCDN certificate application created successfully: %s", d.Id())
+	return resourceScdnCertificateApplyRead(d, m)
+}
+
+func resourceScdnCertificateApplyRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	// Certificate application is a one-time operation, only the issued certificates are refreshed
+	log.Printf("[DEBUG] Reading SCDN certificate application: %s", d.Id())
+
+	expiryTimes := make(map[string]interface{})
+	applyStatuses := make(map[string]interface{})
+	for _, caID := range scdnCertificateApplyCAIDs(d) {
+		detail, err := getScdnCertificateDetail(service, caID)
+		if err != nil {
+			log.Printf("[WARN] Failed to read SCDN certificate %s: %v", caID, err)
+			continue
+		}
+		expiryTimes[caID] = detail.IssuerExpiryTime
+		applyStatuses[caID] = detail.ApplyStatus
+	}
+
+	if err := d.Set("issuer_expiry_time", expiryTimes); err != nil {
+		log.Printf("[WARN] Failed to set issuer_expiry_time: %v", err)
+	}
+	if err := d.Set("apply_status", applyStatuses); err != nil {
+		log.Printf("[WARN] Failed to set apply_status: %v", err)
+	}
+	return nil
+}
+
+func resourceScdnCertificateApplyUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	// The plan marks the certificate outputs unknown for a renewal, so the due check uses the prior state
+	oldExpiryTimes, _ := d.GetChange("issuer_expiry_time")
+	oldCAIDNames, _ := d.GetChange("ca_id_names")
+
+	renewalOf := d.Get("renewal_of").(string)
+	if renewalOf == "" && scdnCertificateRenewalDue(oldExpiryTimes.(map[string]interface{}), d.Get("renew_before_days").(int), time.Now()) {
+		oldCAIDs := scdnCertificateCAIDs(oldCAIDNames.(map[string]interface{}), d.Id())
+
+		response, err := applyScdnCertificate(service, d)
+		if err != nil {
+			return err
+		}
+		// Store the new certificates first so a failed renewal is resumed by the next apply
+		setScdnCertificateApplyResult(d, response)
+		renewalOf = strings.Join(oldCAIDs, ",")
+		if err := d.Set("renewal_of", renewalOf); err != nil {
+			return fmt.Errorf("failed to set renewal_of: %w", err)
+		}
+	}
+
+	if renewalOf != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+		defer cancel()
+
+		if err := renewScdnCertificates(ctx, service, strings.Split(renewalOf, ","), scdnCertificateApplyCAIDs(d)); err != nil {
+			return err
+		}
+		if err := d.Set("renewal_of", ""); err != nil {
+			return fmt.Errorf("failed to set renewal_of: %w", err)
+		}
+	}
+
+	return resourceScdnCertificateApplyRead(d, m)
+}
+
+func resourceScdnCertificateApplyDelete(d *schema.ResourceData, m interface{}) error {
+	// Certificate application cannot be deleted via API
+	// This is a no-op, the resource will just be removed from state
+	log.Printf("[INFO] Deleting SCDN certificate application from state: %s", d.Id())
+	return nil
+}
+
+// resourceScdnCertificateApplyCustomizeDiff plans an update when a certificate expires within
+// renew_before_days or an earlier renewal has not finished, marking the outputs a renewal replaces unknown
+func resourceScdnCertificateApplyCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if renewalOf := d.Get("renewal_of").(string); renewalOf != "" {
+		log.Printf("[INFO] Renewal of SCDN certificates %s has not finished, planning to resume it", renewalOf)
+		return d.SetNewComputed("apply_status")
+	}
+
+	expiryTimes := d.Get("issuer_expiry_time").(map[string]interface{})
+	if !scdnCertificateRenewalDue(expiryTimes, d.Get("renew_before_days").(int), time.Now()) {
+		return nil
+	}
+
+	log.Printf("[INFO] SCDN certificates %s are within their renewal window, planning renewal", d.Id())
+	for _, key := range []string{"ca_id_domains", "ca_id_names", "issuer_expiry_time", "apply_status"} {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renewScdnCertificates waits for the new certificates to be issued, moves every domain bound to an
+// old certificate over to them and deletes the old certificates once nothing is bound to them
+func renewScdnCertificates(ctx context.Context, service *scdn.ScdnService, oldCAIDs, newCAIDs []string) error {
+	log.Printf("[INFO] Renewing SCDN certificates %v with %v", oldCAIDs, newCAIDs)
+
+	issued, err := waitForScdnCertificatesIssued(ctx, service, newCAIDs)
+	if err != nil {
+		return fmt.Errorf("renewal certificates %s were not issued, the old certificates %s are still in use: %w",
+			strings.Join(newCAIDs, ","), strings.Join(oldCAIDs, ","), err)
+	}
+
+	bindings, err := findScdnCertificateBindings(service, oldCAIDs)
+	if err != nil {
+		return fmt.Errorf("failed to find the domains bound to certificates %s, they are still in use: %w", strings.Join(oldCAIDs, ","), err)
+	}
+
+	rebinds, failed := planScdnCertificateRebinds(bindings, issued)
+	for _, binding := range rebinds {
+		log.Printf("[INFO] Rebinding domain %s from certificate %d to %d", binding.Domain, binding.OldCAID, binding.CAID)
+		if _, err := service.BindDomainCert(scdn.DomainCertBindRequest{
+			DomainID: binding.DomainID,
+			CAID:     binding.CAID,
+		}); err != nil {
+			log.Printf("[WARN] Failed to rebind domain %s to renewal certificate %d: %v", binding.Domain, binding.CAID, err)
+			failed = append(failed, fmt.Sprintf("%s (%v)", binding.Domain, err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to move domains to renewal certificates %s: %s; the old certificates %s were not deleted",
+			strings.Join(newCAIDs, ","), strings.Join(failed, ", "), strings.Join(oldCAIDs, ","))
+	}
+
+	log.Printf("[INFO] Deleting replaced SCDN certificates %v", oldCAIDs)
+	if _, err := service.DeleteCertificate(scdn.CASelfDeleteRequest{
+		IDs: strings.Join(oldCAIDs, ","),
+	}); err != nil {
+		return fmt.Errorf("renewal succeeded but failed to delete old certificates %s: %w", strings.Join(oldCAIDs, ","), err)
+	}
+	return nil
+}
+
+// applyScdnCertificate applies for a certificate covering the configured domains
+func applyScdnCertificate(service *scdn.ScdnService, d *schema.ResourceData) (*scdn.CAApplyAddResponse, error) {
 	// Get domains from schema
 	domainsInterface := d.Get("domain").([]interface{})
 	domains := make([]string, len(domainsInterface))
@@ -76,11 +270,15 @@ func resourceScdnCertificateApplyCreate(d *schema.ResourceData, m interface{}) e
 	log.Printf("[INFO] Applying for SCDN certificate for domains: %v", domains)
 	response, err := service.ApplyCertificate(req)
 	if err != nil {
-		return fmt.Errorf("failed to apply for SCDN certificate: %w", err)
+		return nil, fmt.Errorf("failed to apply for SCDN certificate: %w", err)
 	}
 
 	log.Printf("[DEBUG] Certificate application response: %+v", response)
+	return response, nil
+}
 
+// setScdnCertificateApplyResult stores the ID and computed maps of a certificate application
+func setScdnCertificateApplyResult(d *schema.ResourceData, response *scdn.CAApplyAddResponse) {
 	// Convert maps for Terraform
 	caIDDomains := make(map[string]interface{})
 	caIds := make([]string, 0)
@@ -105,22 +303,173 @@ func resourceScdnCertificateApplyCreate(d *schema.ResourceData, m interface{}) e
 	if err := d.Set("ca_id_names", caIDNames); err != nil {
 		log.Printf("[WARN] Failed to set ca_id_names: %v", err)
 	}
+}
 
-	log.Printf("[INFO] SCDN certificate application created successfully: %s", d.Id())
-	return nil
+// scdnCertificateApplyCAIDs returns the certificate IDs of the application
+func scdnCertificateApplyCAIDs(d *schema.ResourceData) []string {
+	return scdnCertificateCAIDs(d.Get("ca_id_names").(map[string]interface{}), d.Id())
 }
 
-func resourceScdnCertificateApplyRead(d *schema.ResourceData, m interface{}) error {
-	// Certificate application is a one-time operation
-	// The read operation just returns the current state
-	// In practice, you might want to query the certificate status
-	log.Printf("[DEBUG] Reading SCDN certificate application: %s", d.Id())
-	return nil
+// scdnCertificateCAIDs returns the sorted keys of ca_id_names, falling back to the resource ID after import
+func scdnCertificateCAIDs(caIDNames map[string]interface{}, id string) []string {
+	caIDs := make([]string, 0, len(caIDNames))
+	for caID := range caIDNames {
+		caIDs = append(caIDs, caID)
+	}
+	if len(caIDs) == 0 && id != "" {
+		caIDs = strings.Split(id, ",")
+	}
+	sort.Strings(caIDs)
+	return caIDs
 }
 
-func resourceScdnCertificateApplyDelete(d *schema.ResourceData, m interface{}) error {
-	// Certificate application cannot be deleted via API
-	// This is a no-op, the resource will just be removed from state
-	log.Printf("[INFO] Deleting SCDN certificate application from state: %s", d.Id())
-	return nil
+func getScdnCertificateDetail(service *scdn.ScdnService, caID string) (*scdn.CertificateDetailInfo, error) {
+	id, err := strconv.Atoi(caID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate ID %q: %w", caID, err)
+	}
+	response, err := service.GetCertificateDetail(scdn.CASelfDetailRequest{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	return &response.Data, nil
+}
+
+// scdnCertificateRenewalDue reports whether any certificate expires within renewBeforeDays of now.
+// Certificates without a known expiry time are ignored.
+func scdnCertificateRenewalDue(expiryTimes map[string]interface{}, renewBeforeDays int, now time.Time) bool {
+	if renewBeforeDays <= 0 {
+		return false
+	}
+	deadline := now.Add(time.Duration(renewBeforeDays) * 24 * time.Hour)
+	for _, value := range expiryTimes {
+		expiry, ok := helper.ParseCertificateTime(value.(string))
+		if ok && expiry.Before(deadline) {
+			return true
+		}
+	}
+	return false
+}
+
+// scdnCertificateBinding is a domain bound to a certificate
+type scdnCertificateBinding struct {
+	DomainID int
+	Domain   string
+	CAID     int
+}
+
+// scdnCertificateRebind moves a domain from an old certificate to its renewal
+type scdnCertificateRebind struct {
+	DomainID int
+	Domain   string
+	OldCAID  int
+	CAID     int
+}
+
+// planScdnCertificateRebinds pairs every bound domain with the issued certificate covering it. Domains
+// no issued certificate covers are returned as failures, since deleting the old certificate would
+// leave them without one.
+func planScdnCertificateRebinds(bindings []scdnCertificateBinding, issued map[string]*scdn.CertificateDetailInfo) ([]scdnCertificateRebind, []string) {
+	rebinds := make([]scdnCertificateRebind, 0, len(bindings))
+	failed := make([]string, 0)
+	for _, binding := range bindings {
+		caID, err := strconv.Atoi(scdnCertificateForDomain(issued, binding.Domain))
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s (not covered)", binding.Domain))
+			continue
+		}
+		rebinds = append(rebinds, scdnCertificateRebind{
+			DomainID: binding.DomainID,
+			Domain:   binding.Domain,
+			OldCAID:  binding.CAID,
+			CAID:     caID,
+		})
+	}
+	return rebinds, failed
+}
+
+// findScdnCertificateBindings returns every domain bound to one of the certificates, including
+// subdomains bound through a wildcard certificate
+func findScdnCertificateBindings(service *scdn.ScdnService, caIDs []string) ([]scdnCertificateBinding, error) {
+	ids := make(map[int]bool, len(caIDs))
+	for _, caID := range caIDs {
+		id, err := strconv.Atoi(caID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate ID %q: %w", caID, err)
+		}
+		ids[id] = true
+	}
+
+	domains, err := listScdnDomains(service)
+	if err != nil {
+		return nil, err
+	}
+
+	bindings := make([]scdnCertificateBinding, 0)
+	for _, domain := range domains {
+		if ids[domain.CAID] {
+			bindings = append(bindings, scdnCertificateBinding{
+				DomainID: domain.ID,
+				Domain:   domain.Domain,
+				CAID:     domain.CAID,
+			})
+		}
+	}
+	return bindings, nil
+}
+
+// waitForScdnCertificatesIssued polls until every certificate is issued and returns their details
+func waitForScdnCertificatesIssued(ctx context.Context, service *scdn.ScdnService, caIDs []string) (map[string]*scdn.CertificateDetailInfo, error) {
+	issued := make(map[string]*scdn.CertificateDetailInfo, len(caIDs))
+	for {
+		for _, caID := range caIDs {
+			if issued[caID] != nil {
+				continue
+			}
+			detail, err := getScdnCertificateDetail(service, caID)
+			if err != nil {
+				log.Printf("[WARN] Failed to query SCDN certificate %s status: %v", caID, err)
+				continue
+			}
+			switch detail.ApplyStatus {
+			case scdn.CertificateApplyStatusIssued:
+				issued[caID] = detail
+			case scdn.CertificateApplyStatusReviewFailed:
+				return nil, fmt.Errorf("certificate %s review failed: %s", caID, detail.Msg)
+			}
+		}
+		if len(issued) == len(caIDs) {
+			return issued, nil
+		}
+		log.Printf("[DEBUG] Waiting for SCDN certificates: %d of %d issued", len(issued), len(caIDs))
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timeout while waiting for certificates to be issued")
+		case <-time.After(10 * time.Second):
+		}
+	}
+}
+
+// scdnCertificateForDomain returns the ID of the certificate covering domain, directly or by wildcard
+func scdnCertificateForDomain(certificates map[string]*scdn.CertificateDetailInfo, domain string) string {
+	caIDs := make([]string, 0, len(certificates))
+	for caID := range certificates {
+		caIDs = append(caIDs, caID)
+	}
+	sort.Strings(caIDs)
+
+	for _, caID := range caIDs {
+		for _, name := range certificates[caID].CADomain {
+			if name == domain {
+				return caID
+			}
+			if strings.HasPrefix(name, "*.") {
+				if i := strings.Index(domain, "."); i > 0 && domain[i+1:] == name[2:] {
+					return caID
+				}
+			}
+		}
+	}
+	return ""
 }
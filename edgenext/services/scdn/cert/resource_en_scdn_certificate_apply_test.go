@@ -0,0 +1,166 @@
+package cert
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
+)
+
+func TestScdnCertificateRenewalDue(t *testing.T) {
+	// 2024-03-10 00:00:00 UTC is 08:00 in the API time zone
+	now := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		expiryTimes     map[string]interface{}
+		renewBeforeDays int
+		want            bool
+	}{
+		{
+			name:            "renewal disabled",
+			expiryTimes:     map[string]interface{}{"1": "2024-03-11 00:00:00"},
+			renewBeforeDays: 0,
+			want:            false,
+		},
+		{
+			name:            "expires within the window",
+			expiryTimes:     map[string]interface{}{"1": "2024-04-01 00:00:00"},
+			renewBeforeDays: 30,
+			want:            true,
+		},
+		{
+			name:            "expires after the window",
+			expiryTimes:     map[string]interface{}{"1": "2024-05-01 00:00:00"},
+			renewBeforeDays: 30,
+			want:            false,
+		},
+		{
+			name:            "window boundary is in the API time zone",
+			expiryTimes:     map[string]interface{}{"1": "2024-04-09 07:59:59"},
+			renewBeforeDays: 30,
+			want:            true,
+		},
+		{
+			name:            "just after the window boundary",
+			expiryTimes:     map[string]interface{}{"1": "2024-04-09 08:00:00"},
+			renewBeforeDays: 30,
+			want:            false,
+		},
+		{
+			name:            "unix timestamp",
+			expiryTimes:     map[string]interface{}{"1": "1710460800"},
+			renewBeforeDays: 7,
+			want:            true,
+		},
+		{
+			name:            "any certificate of the application is enough",
+			expiryTimes:     map[string]interface{}{"1": "2025-01-01 00:00:00", "2": "2024-03-12 00:00:00"},
+			renewBeforeDays: 7,
+			want:            true,
+		},
+		{
+			name:            "unknown expiry is ignored",
+			expiryTimes:     map[string]interface{}{"1": "", "2": "soon"},
+			renewBeforeDays: 30,
+			want:            false,
+		},
+		{
+			name:            "no certificates",
+			expiryTimes:     map[string]interface{}{},
+			renewBeforeDays: 30,
+			want:            false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scdnCertificateRenewalDue(tt.expiryTimes, tt.renewBeforeDays, now); got != tt.want {
+				t.Errorf("scdnCertificateRenewalDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScdnCertificateForDomain(t *testing.T) {
+	certificates := map[string]*scdn.CertificateDetailInfo{
+		"20": {CADomain: []string{"*.example.com"}},
+		"10": {CADomain: []string{"example.com", "www.example.com"}},
+	}
+
+	tests := []struct {
+		domain string
+		want   string
+	}{
+		{domain: "example.com", want: "10"},
+		{domain: "www.example.com", want: "10"},
+		{domain: "api.example.com", want: "20"},
+		{domain: "a.b.example.com", want: ""},
+		{domain: "example.org", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			if got := scdnCertificateForDomain(certificates, tt.domain); got != tt.want {
+				t.Errorf("scdnCertificateForDomain(%q) = %q, want %q", tt.domain, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanScdnCertificateRebinds(t *testing.T) {
+	issued := map[string]*scdn.CertificateDetailInfo{
+		"200": {CADomain: []string{"example.com"}},
+		"201": {CADomain: []string{"*.example.com"}},
+	}
+
+	tests := []struct {
+		name        string
+		bindings    []scdnCertificateBinding
+		wantRebinds []scdnCertificateRebind
+		wantFailed  []string
+	}{
+		{
+			name:        "nothing bound",
+			bindings:    nil,
+			wantRebinds: []scdnCertificateRebind{},
+			wantFailed:  []string{},
+		},
+		{
+			name: "domains move to the certificate covering them",
+			bindings: []scdnCertificateBinding{
+				{DomainID: 1, Domain: "example.com", CAID: 100},
+				{DomainID: 2, Domain: "cdn.example.com", CAID: 101},
+			},
+			wantRebinds: []scdnCertificateRebind{
+				{DomainID: 1, Domain: "example.com", OldCAID: 100, CAID: 200},
+				{DomainID: 2, Domain: "cdn.example.com", OldCAID: 101, CAID: 201},
+			},
+			wantFailed: []string{},
+		},
+		{
+			name: "uncovered domains are failures",
+			bindings: []scdnCertificateBinding{
+				{DomainID: 1, Domain: "example.com", CAID: 100},
+				{DomainID: 3, Domain: "example.org", CAID: 100},
+			},
+			wantRebinds: []scdnCertificateRebind{
+				{DomainID: 1, Domain: "example.com", OldCAID: 100, CAID: 200},
+			},
+			wantFailed: []string{"example.org (not covered)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rebinds, failed := planScdnCertificateRebinds(tt.bindings, issued)
+			if !reflect.DeepEqual(rebinds, tt.wantRebinds) {
+				t.Errorf("planScdnCertificateRebinds() rebinds = %+v, want %+v", rebinds, tt.wantRebinds)
+			}
+			if !reflect.DeepEqual(failed, tt.wantFailed) {
+				t.Errorf("planScdnCertificateRebinds() failed = %v, want %v", failed, tt.wantFailed)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package cert
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ResourceEdgenextScdnCertificateBatchBinding returns the SCDN certificate batch binding resource
+func ResourceEdgenextScdnCertificateBatchBinding() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceScdnCertificateBatchBindingCreate,
+		Read:   resourceScdnCertificateBatchBindingRead,
+		Update: resourceScdnCertificateBatchBindingUpdate,
+		Delete: resourceScdnCertificateBatchBindingDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceScdnCertificateBatchBindingImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"ca_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "The ID of the certificate to bind. Changing it rebinds all domains to the new certificate in one call",
+			},
+			"domain_ids": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "The IDs of the domains to bind the certificate to",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+	}
+}
+
+func resourceScdnCertificateBatchBindingCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	caID := d.Get("ca_id").(int)
+	domainIDs := expandIntSet(d.Get("domain_ids").(*schema.Set))
+
+	log.Printf("[INFO] Binding SCDN certificate %d to %d domain(s)", caID, len(domainIDs))
+	if _, err := service.BatchOperateCertificates(scdn.CABatchOperatRequest{
+		Operat:    scdn.CABatchOperatBind,
+		CAID:      caID,
+		DomainIDs: domainIDs,
+	}); err != nil {
+		return fmt.Errorf("failed to create SCDN certificate batch binding: %w", err)
+	}
+
+	d.SetId(strconv.Itoa(caID))
+	if err := checkScdnCertificateBatchBinding(service, caID, domainIDs); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] SCDN certificate batch binding created successfully: %s", d.Id())
+	return resourceScdnCertificateBatchBindingRead(d, m)
+}
+
+func resourceScdnCertificateBatchBindingRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	caID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid certificate ID: %w", err)
+	}
+
+	log.Printf("[DEBUG] Reading SCDN certificate batch binding: %d", caID)
+	certificates, err := listScdnDomainCertificates(service)
+	if err != nil {
+		return fmt.Errorf("failed to read SCDN certificate batch binding: %w", err)
+	}
+
+	// Only report the managed domains that are still bound
+	managed := d.Get("domain_ids").(*schema.Set)
+	domainIDs := make([]int, 0)
+	for domainID, boundCAID := range certificates {
+		if boundCAID == caID && managed.Contains(domainID) {
+			domainIDs = append(domainIDs, domainID)
+		}
+	}
+
+	if err := d.Set("ca_id", caID); err != nil {
+		return fmt.Errorf("error setting ca_id: %w", err)
+	}
+	if err := d.Set("domain_ids", domainIDs); err != nil {
+		return fmt.Errorf("error setting domain_ids: %w", err)
+	}
+
+	log.Printf("[INFO] SCDN certificate batch binding read successfully: %s", d.Id())
+	return nil
+}
+
+func resourceScdnCertificateBatchBindingUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	caID := d.Get("ca_id").(int)
+	oldDomains, newDomains := d.GetChange("domain_ids")
+	removed := expandIntSet(oldDomains.(*schema.Set).Difference(newDomains.(*schema.Set)))
+
+	// Binding replaces the current certificate of a domain, so a new ca_id rebinds every domain
+	toBind := expandIntSet(newDomains.(*schema.Set).Difference(oldDomains.(*schema.Set)))
+	if d.HasChange("ca_id") {
+		toBind = expandIntSet(newDomains.(*schema.Set))
+	}
+
+	if len(toBind) > 0 {
+		log.Printf("[INFO] Binding SCDN certificate %d to %d domain(s)", caID, len(toBind))
+		if _, err := service.BatchOperateCertificates(scdn.CABatchOperatRequest{
+			Operat:    scdn.CABatchOperatBind,
+			CAID:      caID,
+			DomainIDs: toBind,
+		}); err != nil {
+			return fmt.Errorf("failed to update SCDN certificate batch binding: %w", err)
+		}
+	}
+
+	if len(removed) > 0 {
+		oldCAID, _ := d.GetChange("ca_id")
+		if err := unbindScdnCertificateDomains(service, oldCAID.(int), removed); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(strconv.Itoa(caID))
+	if err := checkScdnCertificateBatchBinding(service, caID, toBind); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] SCDN certificate batch binding updated successfully: %s", d.Id())
+	return resourceScdnCertificateBatchBindingRead(d, m)
+}
+
+func resourceScdnCertificateBatchBindingDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	caID := d.Get("ca_id").(int)
+	if err := unbindScdnCertificateDomains(service, caID, expandIntSet(d.Get("domain_ids").(*schema.Set))); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	log.Printf("[INFO] SCDN certificate batch binding deleted successfully: %d", caID)
+	return nil
+}
+
+// resourceScdnCertificateBatchBindingImport imports every domain currently bound to the certificate
+func resourceScdnCertificateBatchBindingImport(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	caID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate ID: %w", err)
+	}
+
+	certificates, err := listScdnDomainCertificates(service)
+	if err != nil {
+		return nil, err
+	}
+	domainIDs := make([]int, 0)
+	for domainID, boundCAID := range certificates {
+		if boundCAID == caID {
+			domainIDs = append(domainIDs, domainID)
+		}
+	}
+	if len(domainIDs) == 0 {
+		return nil, fmt.Errorf("SCDN certificate %d is not bound to any domain", caID)
+	}
+
+	if err := d.Set("ca_id", caID); err != nil {
+		return nil, fmt.Errorf("error setting ca_id: %w", err)
+	}
+	if err := d.Set("domain_ids", domainIDs); err != nil {
+		return nil, fmt.Errorf("error setting domain_ids: %w", err)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// unbindScdnCertificateDomains unbinds the domains that are still bound to caID, domains rebound
+// to another certificate in the meantime are left alone
+func unbindScdnCertificateDomains(service *scdn.ScdnService, caID int, domainIDs []int) error {
+	certificates, err := listScdnDomainCertificates(service)
+	if err != nil {
+		return err
+	}
+
+	toUnbind := make([]int, 0, len(domainIDs))
+	for _, domainID := range domainIDs {
+		if certificates[domainID] == caID {
+			toUnbind = append(toUnbind, domainID)
+		}
+	}
+	if len(toUnbind) == 0 {
+		return nil
+	}
+
+	log.Printf("[INFO] Unbinding SCDN certificate %d from %d domain(s)", caID, len(toUnbind))
+	if _, err := service.BatchOperateCertificates(scdn.CABatchOperatRequest{
+		Operat:    scdn.CABatchOperatUnbind,
+		DomainIDs: toUnbind,
+	}); err != nil {
+		return fmt.Errorf("failed to unbind SCDN certificate %d: %w", caID, err)
+	}
+	return nil
+}
+
+// checkScdnCertificateBatchBinding returns an error listing the domains that are not bound to caID
+// after a batch bind
+func checkScdnCertificateBatchBinding(service *scdn.ScdnService, caID int, domainIDs []int) error {
+	if len(domainIDs) == 0 {
+		return nil
+	}
+
+	certificates, err := listScdnDomainCertificates(service)
+	if err != nil {
+		return err
+	}
+
+	failed := make([]int, 0)
+	for _, domainID := range domainIDs {
+		if certificates[domainID] != caID {
+			failed = append(failed, domainID)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("SCDN certificate %d was not bound to %d of %d domain(s): %v", caID, len(failed), len(domainIDs), failed)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package cert
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceEdgenextScdnCertificateBatchOperation returns the SCDN certificate batch operation resource
+func ResourceEdgenextScdnCertificateBatchOperation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceScdnCertificateBatchOperationCreate,
+		Read:   resourceScdnCertificateBatchOperationRead,
+		Delete: resourceScdnCertificateBatchOperationDelete,
+
+		CustomizeDiff: resourceScdnCertificateBatchOperationCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"operation": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{scdn.CABatchOperatBind, scdn.CABatchOperatUnbind, scdn.CABatchOperatDelete}, false),
+				Description:  "The operation: bind (bind ca_id to domain_ids), unbind (unbind the certificates of domain_ids), delete (delete ca_ids)",
+			},
+			"ca_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The ID of the certificate to bind, required for bind",
+			},
+			"domain_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The IDs of the domains, required for bind and unbind",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"ca_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The IDs of the certificates to delete, required for delete",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+	}
+}
+
+// resourceScdnCertificateBatchOperationCustomizeDiff checks that the arguments of the operation are set
+func resourceScdnCertificateBatchOperationCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	raw := d.GetRawConfig()
+	operation := raw.GetAttr("operation")
+	if !operation.IsKnown() || operation.IsNull() {
+		return nil
+	}
+
+	var required []string
+	switch operation.AsString() {
+	case scdn.CABatchOperatBind:
+		required = []string{"ca_id", "domain_ids"}
+	case scdn.CABatchOperatUnbind:
+		required = []string{"domain_ids"}
+	case scdn.CABatchOperatDelete:
+		required = []string{"ca_ids"}
+	}
+	for _, key := range required {
+		if raw.GetAttr(key).IsNull() {
+			return fmt.Errorf("%s is required for the %s operation", key, operation.AsString())
+		}
+	}
+	return nil
+}
+
+func resourceScdnCertificateBatchOperationCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	req := scdn.CABatchOperatRequest{
+		Operat: d.Get("operation").(string),
+	}
+	switch req.Operat {
+	case scdn.CABatchOperatBind:
+		req.CAID = d.Get("ca_id").(int)
+		req.DomainIDs = expandIntSet(d.Get("domain_ids").(*schema.Set))
+	case scdn.CABatchOperatUnbind:
+		req.DomainIDs = expandIntSet(d.Get("domain_ids").(*schema.Set))
+	case scdn.CABatchOperatDelete:
+		req.CAIDs = expandIntSet(d.Get("ca_ids").(*schema.Set))
+	}
+
+	log.Printf("[INFO] Running SCDN certificate batch operation: %+v", req)
+	if _, err := service.BatchOperateCertificates(req); err != nil {
+		return fmt.Errorf("failed to run SCDN certificate batch operation: %w", err)
+	}
+
+	d.SetId(fmt.Sprintf("%s-%d", req.Operat, time.Now().Unix()))
+
+	log.Printf("[INFO] SCDN certificate batch operation completed successfully: %s", d.Id())
+	return resourceScdnCertificateBatchOperationRead(d, m)
+}
+
+func resourceScdnCertificateBatchOperationRead(d *schema.ResourceData, m interface{}) error {
+	// Batch operation is a one-time operation, there is nothing to refresh
+	log.Printf("[DEBUG] Reading SCDN certificate batch operation: %s", d.Id())
+	return nil
+}
+
+func resourceScdnCertificateBatchOperationDelete(d *schema.ResourceData, m interface{}) error {
+	// Batch operations cannot be undone, only remove from state
+	log.Printf("[INFO] Deleting SCDN certificate batch operation from state: %s", d.Id())
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,176 @@
+package cert
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Values of issuer_expiry_time_auto_renew_status
+const (
+	scdnCertificateAutoRenewOn  = 1
+	scdnCertificateAutoRenewOff = 2
+)
+
+// ResourceEdgenextScdnCertificateInfo returns the SCDN certificate info resource
+func ResourceEdgenextScdnCertificateInfo() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceScdnCertificateInfoCreate,
+		Read:   resourceScdnCertificateInfoRead,
+		Update: resourceScdnCertificateInfoUpdate,
+		Delete: resourceScdnCertificateInfoDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"certificate_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of an existing certificate",
+			},
+			"ca_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The certificate name",
+			},
+			"auto_renew": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the certificate is renewed automatically before it expires",
+			},
+			"product_flag": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The product flag",
+			},
+			// Computed fields
+			"issuer_expiry_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The certificate expiry time",
+			},
+			"ca_domain": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The domains in the certificate",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceScdnCertificateInfoCreate(d *schema.ResourceData, m interface{}) error {
+	d.SetId(d.Get("certificate_id").(string))
+	if err := editScdnCertificateInfo(d, m); err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] SCDN certificate info managed successfully: %s", d.Id())
+	return resourceScdnCertificateInfoRead(d, m)
+}
+
+func resourceScdnCertificateInfoRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	certID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid certificate ID: %w", err)
+	}
+
+	log.Printf("[DEBUG] Reading SCDN certificate info: %d", certID)
+	response, err := service.GetCertificateDetail(scdn.CASelfDetailRequest{ID: certID})
+	if err != nil {
+		return fmt.Errorf("failed to read SCDN certificate info: %w", err)
+	}
+
+	if response.Data.ID == "" {
+		log.Printf("[WARN] SCDN certificate not found: %d", certID)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("certificate_id", d.Id()); err != nil {
+		return fmt.Errorf("error setting certificate_id: %w", err)
+	}
+	if err := d.Set("ca_name", response.Data.CAName); err != nil {
+		return fmt.Errorf("error setting ca_name: %w", err)
+	}
+	if err := d.Set("auto_renew", response.Data.IssuerExpiryTimeAutoRenewStatus == scdnCertificateAutoRenewOn); err != nil {
+		return fmt.Errorf("error setting auto_renew: %w", err)
+	}
+	if err := d.Set("issuer_expiry_time", response.Data.IssuerExpiryTime); err != nil {
+		return fmt.Errorf("error setting issuer_expiry_time: %w", err)
+	}
+	if err := d.Set("ca_domain", response.Data.CADomain); err != nil {
+		return fmt.Errorf("error setting ca_domain: %w", err)
+	}
+
+	log.Printf("[INFO] SCDN certificate info read successfully: %s", d.Id())
+	return nil
+}
+
+func resourceScdnCertificateInfoUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChanges("ca_name", "auto_renew") {
+		if err := editScdnCertificateInfo(d, m); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[INFO] SCDN certificate info updated successfully: %s", d.Id())
+	return resourceScdnCertificateInfoRead(d, m)
+}
+
+func resourceScdnCertificateInfoDelete(d *schema.ResourceData, m interface{}) error {
+	// The certificate itself is not managed by this resource, only remove from state
+	log.Printf("[INFO] Deleting SCDN certificate info from state: %s", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// editScdnCertificateInfo sends the configured metadata, unset arguments are left unchanged
+func editScdnCertificateInfo(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	certID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid certificate ID: %w", err)
+	}
+
+	req := scdn.CAInfoEditRequest{
+		ID:          certID,
+		ProductFlag: d.Get("product_flag").(string),
+	}
+	raw := d.GetRawConfig()
+	if !raw.GetAttr("ca_name").IsNull() {
+		req.CAName = d.Get("ca_name").(string)
+	}
+	if !raw.GetAttr("auto_renew").IsNull() {
+		status := scdnCertificateAutoRenewOff
+		if d.Get("auto_renew").(bool) {
+			status = scdnCertificateAutoRenewOn
+		}
+		req.IssuerExpiryTimeAutoRenewStatus = &status
+	}
+	if req.CAName == "" && req.IssuerExpiryTimeAutoRenewStatus == nil {
+		return nil
+	}
+
+	log.Printf("[INFO] Editing SCDN certificate info: %d", certID)
+	if _, err := service.EditCertificateInfo(req); err != nil {
+		return fmt.Errorf("failed to edit SCDN certificate info: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package cert
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/helper"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ResourceEdgenextScdnCertificateUpload returns the SCDN certificate file upload resource
+func ResourceEdgenextScdnCertificateUpload() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceScdnCertificateUploadCreate,
+		Read:   resourceScdnCertificateUploadRead,
+		Update: resourceScdnCertificateUploadUpdate,
+		Delete: resourceScdnCertificateUploadDelete,
+
+		CustomizeDiff: resourceScdnCertificateUploadCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"file_path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of a PEM bundle holding the certificate chain and private key, or of a PFX/PKCS#12 file (.pfx, .p12)",
+			},
+			"file_hash": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Hash of the file, e.g. filemd5(file_path), so that changing the file content uploads it again",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+				Description: "Password of the PFX file",
+			},
+			"ca_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The certificate name",
+			},
+			"product_flag": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The product flag",
+			},
+			// Computed fields
+			"ca_sn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The certificate serial number",
+			},
+			"issuer": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The certificate issuer",
+			},
+			"issuer_expiry_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The certificate expiry time",
+			},
+			"ca_domain": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The domains in the certificate",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// resourceScdnCertificateUploadCustomizeDiff checks that the file exists and validates PEM bundles
+func resourceScdnCertificateUploadCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	filePath := d.GetRawConfig().GetAttr("file_path")
+	if !filePath.IsKnown() || filePath.IsNull() {
+		return nil
+	}
+	// Nothing to upload once created, the file may have been removed since
+	if d.Id() != "" && !d.HasChanges("file_path", "file_hash") {
+		return nil
+	}
+
+	content, err := os.ReadFile(filePath.AsString())
+	if err != nil {
+		return fmt.Errorf("failed to read certificate file: %w", err)
+	}
+	if isPfxFile(filePath.AsString()) {
+		return nil
+	}
+
+	certPEM, keyPEM := splitPEMBundle(content)
+	if keyPEM == "" {
+		return fmt.Errorf("certificate file %s does not contain a private key", filePath.AsString())
+	}
+	if _, err := helper.ValidateCertificate(certPEM, keyPEM, time.Now()); err != nil {
+		return fmt.Errorf("invalid certificate file %s: %w", filePath.AsString(), err)
+	}
+	return nil
+}
+
+func resourceScdnCertificateUploadCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	req := scdn.CASelfAddRequest{
+		CAName:      d.Get("ca_name").(string),
+		FilePath:    d.Get("file_path").(string),
+		Password:    d.Get("password").(string),
+		ProductFlag: d.Get("product_flag").(string),
+	}
+
+	log.Printf("[INFO] Uploading SCDN certificate %s from %s", req.CAName, req.FilePath)
+	response, err := service.UploadCertificate(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload SCDN certificate: %w", err)
+	}
+
+	d.SetId(response.Data.ID)
+	if err := d.Set("ca_sn", response.Data.CASN); err != nil {
+		log.Printf("[WARN] Failed to set certificate serial number: %v", err)
+	}
+
+	log.Printf("[INFO] SCDN certificate uploaded successfully: %s", d.Id())
+	return resourceScdnCertificateUploadRead(d, m)
+}
+
+func resourceScdnCertificateUploadRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	certID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid certificate ID: %w", err)
+	}
+
+	log.Printf("[DEBUG] Reading SCDN certificate: %d", certID)
+	response, err := service.GetCertificateDetail(scdn.CASelfDetailRequest{ID: certID})
+	if err != nil {
+		return fmt.Errorf("failed to read SCDN certificate: %w", err)
+	}
+
+	if response.Data.ID == "" {
+		log.Printf("[WARN] SCDN certificate not found: %d", certID)
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("ca_name", response.Data.CAName); err != nil {
+		return fmt.Errorf("error setting ca_name: %w", err)
+	}
+	if err := d.Set("issuer", response.Data.Issuer); err != nil {
+		return fmt.Errorf("error setting issuer: %w", err)
+	}
+	if err := d.Set("issuer_expiry_time", response.Data.IssuerExpiryTime); err != nil {
+		return fmt.Errorf("error setting issuer_expiry_time: %w", err)
+	}
+	if err := d.Set("ca_domain", response.Data.CADomain); err != nil {
+		return fmt.Errorf("error setting ca_domain: %w", err)
+	}
+
+	log.Printf("[INFO] SCDN certificate read successfully: %s", d.Id())
+	return nil
+}
+
+func resourceScdnCertificateUploadUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	certID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid certificate ID: %w", err)
+	}
+
+	if d.HasChange("ca_name") {
+		req := scdn.CAEditNameRequest{
+			ID:          certID,
+			CAName:      d.Get("ca_name").(string),
+			ProductFlag: d.Get("product_flag").(string),
+		}
+
+		log.Printf("[INFO] Updating SCDN certificate name: %+v", req)
+		if _, err := service.EditCertificateName(req); err != nil {
+			return fmt.Errorf("failed to update certificate name: %w", err)
+		}
+	}
+
+	return resourceScdnCertificateUploadRead(d, m)
+}
+
+func resourceScdnCertificateUploadDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	certID := d.Id()
+	req := scdn.CASelfDeleteRequest{
+		IDs:         certID,
+		ProductFlag: d.Get("product_flag").(string),
+	}
+
+	log.Printf("[INFO] Deleting SCDN certificate: %+v", req)
+	if _, err := service.DeleteCertificate(req); err != nil {
+		return fmt.Errorf("failed to delete SCDN certificate: %w", err)
+	}
+
+	d.SetId("")
+	log.Printf("[INFO] SCDN certificate deleted successfully: %s", certID)
+	return nil
+}
+
+func isPfxFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".pfx" || ext == ".p12"
+}
+
+// splitPEMBundle returns the certificate blocks and the private key block of a PEM bundle
+func splitPEMBundle(content []byte) (certPEM, keyPEM string) {
+	var certs, key strings.Builder
+	for {
+		var block *pem.Block
+		block, content = pem.Decode(content)
+		if block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			certs.Write(pem.EncodeToMemory(block))
+		} else if strings.HasSuffix(block.Type, "PRIVATE KEY") && key.Len() == 0 {
+			key.Write(pem.EncodeToMemory(block))
+		}
+	}
+	return certs.String(), key.String()
+}
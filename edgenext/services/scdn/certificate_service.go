@@ -277,3 +277,77 @@ func (s *ScdnService) ExportCertificate(req CASelfExportRequest) (*CASelfExportR
 
 	return response, nil
 }
+
+// BatchOperateCertificates binds, unbinds or deletes certificates in one call
+func (s *ScdnService) BatchOperateCertificates(req CABatchOperatRequest) (*CABatchOperatResponse, error) {
+	ctx := context.Background()
+
+	var response CABatchOperatResponse
+	err := s.callSCDNAPI(ctx, MethodPOST, EndpointCABatchOperat, req, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s certificates in batch: %w", req.Operat, err)
+	}
+
+	return &response, nil
+}
+
+// EditCertificateInfo edits certificate metadata without re-uploading the certificate
+func (s *ScdnService) EditCertificateInfo(req CAInfoEditRequest) (*CAInfoEditResponse, error) {
+	ctx := context.Background()
+
+	var response CAInfoEditResponse
+	err := s.callSCDNAPI(ctx, MethodPOST, EndpointCAInfoEdit, req, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to edit certificate info: %w", err)
+	}
+
+	return &response, nil
+}
+
+// UploadCertificate uploads a PEM bundle or PFX file as a new certificate
+func (s *ScdnService) UploadCertificate(req CASelfAddRequest) (*CASelfAddResponse, error) {
+	ctx := context.Background()
+
+	scdnClient, err := s.client.ScdnClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SCDN client: %w", err)
+	}
+
+	params := map[string]string{
+		"ca_name": req.CAName,
+	}
+	if req.Password != "" {
+		params["password"] = req.Password
+	}
+	if req.ProductFlag != "" {
+		params["product_flag"] = req.ProductFlag
+	}
+
+	resp, err := scdnClient.Upload(ctx, EndpointCASelfAdd, params, "file", req.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload certificate: %w", err)
+	}
+	if resp.Status.Code != 1 {
+		return nil, fmt.Errorf("API error: %s (code: %d)", resp.Status.Message, resp.Status.Code)
+	}
+
+	response := &CASelfAddResponse{
+		Status: Status{
+			Code:    resp.Status.Code,
+			Message: resp.Status.Message,
+		},
+	}
+
+	if resp.Data != nil {
+		dataBytes, err := json.Marshal(resp.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal response data: %w", err)
+		}
+
+		if err := json.Unmarshal(dataBytes, &response.Data); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal certificate upload data: %w", err)
+		}
+	}
+
+	return response, nil
+}
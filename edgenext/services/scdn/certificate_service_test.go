@@ -253,3 +253,68 @@ func TestScdnService_ExportCertificate(t *testing.T) {
 		})
 	}
 }
+
+func TestScdnService_BatchOperateCertificates(t *testing.T) {
+	tests := []struct {
+		name string // description of this test case
+		req  CABatchOperatRequest
+	}{
+		{
+			name: "Test BatchOperateCertificates bind",
+			req: CABatchOperatRequest{
+				Operat:    CABatchOperatBind,
+				CAID:      372,             // Replace with actual certificate ID for testing
+				DomainIDs: []int{101, 102}, // Replace with actual domain IDs for testing
+			},
+		},
+	}
+	if !isIntegrationTest() {
+		t.Skip("Skipping integration test: set EDGENEXT_ACCESS_KEY and EDGENEXT_SECRET_KEY to run")
+	}
+
+	client := createTestClient(t)
+	service := NewScdnService(client)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.BatchOperateCertificates(tt.req)
+			if err != nil {
+				t.Errorf("ScdnService.BatchOperateCertificates() error = %v", err)
+				return
+			}
+			t.Logf("Response: %+v", got)
+		})
+	}
+}
+
+func TestScdnService_EditCertificateInfo(t *testing.T) {
+	autoRenew := 1
+	tests := []struct {
+		name string // description of this test case
+		req  CAInfoEditRequest
+	}{
+		{
+			name: "Test EditCertificateInfo",
+			req: CAInfoEditRequest{
+				ID:                              375, // Replace with actual certificate ID for testing
+				CAName:                          "test-certificate-info",
+				IssuerExpiryTimeAutoRenewStatus: &autoRenew,
+			},
+		},
+	}
+	if !isIntegrationTest() {
+		t.Skip("Skipping integration test: set EDGENEXT_ACCESS_KEY and EDGENEXT_SECRET_KEY to run")
+	}
+
+	client := createTestClient(t)
+	service := NewScdnService(client)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.EditCertificateInfo(tt.req)
+			if err != nil {
+				t.Errorf("ScdnService.EditCertificateInfo() error = %v", err)
+				return
+			}
+			t.Logf("Response: %+v", got)
+		})
+	}
+}
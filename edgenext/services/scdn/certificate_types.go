@@ -116,6 +116,14 @@ type CertificateDetailInfo struct {
 	AuthenticationUsableDomain      string   `json:"authentication_usable_domain"`
 }
 
+// Certificate apply_status values
+const (
+	CertificateApplyStatusApplying     = "1" // Applying
+	CertificateApplyStatusIssued       = "2" // Issued
+	CertificateApplyStatusReviewFailed = "3" // Review failed
+	CertificateApplyStatusUploaded     = "4" // Uploaded
+)
+
 // CASelfDeleteRequest certificate delete request
 type CASelfDeleteRequest struct {
 	IDs         string `json:"ids"` // Certificate IDs, comma separated
@@ -179,6 +187,55 @@ type CAApplyAddData struct {
 	CAIDNames   map[string]string `json:"ca_id_names"`   // ca_id: ca_name
 }
 
+// Certificate batch operations
+const (
+	CABatchOperatBind   = "bind"   // Bind one certificate to many domains
+	CABatchOperatUnbind = "unbind" // Unbind the certificates of many domains
+	CABatchOperatDelete = "delete" // Delete many certificates
+)
+
+// CABatchOperatRequest batch certificate operation request
+type CABatchOperatRequest struct {
+	Operat    string `json:"operat"`               // Operation: bind, unbind, delete
+	CAID      int    `json:"ca_id,omitempty"`      // Certificate ID, for bind
+	DomainIDs []int  `json:"domain_ids,omitempty"` // Domain IDs, for bind and unbind
+	CAIDs     []int  `json:"ca_ids,omitempty"`     // Certificate IDs, for delete
+}
+
+// CABatchOperatResponse batch certificate operation response
+type CABatchOperatResponse struct {
+	Status Status      `json:"status"`
+	Data   interface{} `json:"data"`
+}
+
+// CAInfoEditRequest certificate info edit request
+type CAInfoEditRequest struct {
+	ID                              int    `json:"id"`                                             // Certificate ID
+	CAName                          string `json:"ca_name,omitempty"`                              // Certificate name
+	IssuerExpiryTimeAutoRenewStatus *int   `json:"issuer_expiry_time_auto_renew_status,omitempty"` // Auto renew: 1-on, 2-off
+	ProductFlag                     string `json:"product_flag,omitempty"`                         // Product flag
+}
+
+// CAInfoEditResponse certificate info edit response
+type CAInfoEditResponse struct {
+	Status Status      `json:"status"`
+	Data   interface{} `json:"data"`
+}
+
+// CASelfAddRequest certificate file upload request
+type CASelfAddRequest struct {
+	CAName      string // Certificate name
+	FilePath    string // Path of a PEM bundle (certificate chain and key) or a PFX/PKCS#12 file
+	Password    string // PFX password
+	ProductFlag string // Product flag
+}
+
+// CASelfAddResponse certificate file upload response
+type CASelfAddResponse struct {
+	Status Status         `json:"status"`
+	Data   CATextSaveData `json:"data"`
+}
+
 // CASelfExportRequest certificate export request
 type CASelfExportRequest struct {
 	ID          interface{} `json:"id"` // Certificate ID(s), can be string or array
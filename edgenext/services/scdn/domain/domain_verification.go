@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// verificationChallenge is the DNS record the platform asks for to prove ownership of a domain
+type verificationChallenge struct {
+	Name  string
+	Type  string
+	Value string
+}
+
+// expandVerificationChallenge returns the configured verification_record, empty when none is set
+func expandVerificationChallenge(d *schema.ResourceData) verificationChallenge {
+	list := d.Get("verification_record").([]interface{})
+	if len(list) == 0 || list[0] == nil {
+		return verificationChallenge{}
+	}
+	m := list[0].(map[string]interface{})
+	return verificationChallenge{
+		Name:  strings.ToLower(strings.TrimSuffix(m["name"].(string), ".")),
+		Type:  strings.ToUpper(m["type"].(string)),
+		Value: m["value"].(string),
+	}
+}
+
+// checkVerificationChallenge refuses a challenge that is the access CNAME of the domain, which must
+// stay in place once the domain is online
+func checkVerificationChallenge(challenge verificationChallenge, domain string) error {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	if challenge.Type == "CNAME" && (challenge.Name == domain || challenge.Name == "@") {
+		return fmt.Errorf("verification_record %s is the access CNAME of the domain, not an ownership challenge, manage it with edgenext_sdns_cdn_cname_binding instead", challenge.Name)
+	}
+	return nil
+}
+
+// verificationRecordName returns the name of the challenge record relative to zone. A name without
+// the zone suffix is taken as relative already.
+func verificationRecordName(name, zone string) string {
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	switch {
+	case name == zone:
+		return "@"
+	case strings.HasSuffix(name, "."+zone):
+		return strings.TrimSuffix(name, "."+zone)
+	}
+	return name
+}
+
+// verifyDomainOwnership creates the challenge record in the SDNS zone and waits until the access of
+// the domain is online. Only the challenge record created here is deleted, and only once the domain
+// is online, so that a verification that is still pending can complete later.
+func verifyDomainOwnership(ctx context.Context, client *connectivity.EdgeNextClient, domainID, zoneID int, challenge verificationChallenge) error {
+	dnsService := sdns.NewSdnsService(client)
+	zone, err := dnsService.GetDnsDomainInfo(zoneID)
+	if err != nil {
+		return fmt.Errorf("failed to get SDNS domain %d for the verification record: %w", zoneID, err)
+	}
+
+	name := verificationRecordName(challenge.Name, zone.Domain)
+	log.Printf("[INFO] Creating %s verification record %s in SDNS domain %s", challenge.Type, name, zone.Domain)
+	recordID, err := dnsService.AddDnsRecord(sdns.DnsRecordAddRequest{
+		DomainID:     zoneID,
+		RecordName:   name,
+		RecordType:   challenge.Type,
+		RecordView:   sdns.DnsRecordViewDefault,
+		RecordValue:  challenge.Value,
+		RecordTTL:    600,
+		RecordRemark: fmt.Sprintf("SCDN ownership verification of domain %d", domainID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create verification record: %w", err)
+	}
+
+	if err := waitForDomainAccess(ctx, scdn.NewScdnService(client), domainID); err != nil {
+		return fmt.Errorf("%w, verification record %d is kept in SDNS domain %s so that the verification can complete, delete it once the domain is online", err, recordID, zone.Domain)
+	}
+
+	log.Printf("[INFO] Deleting verification record %d in SDNS domain %s", recordID, zone.Domain)
+	if err := dnsService.DeleteDnsRecord(recordID, zoneID); err != nil {
+		return fmt.Errorf("domain is online but failed to delete verification record %d: %w", recordID, err)
+	}
+	return nil
+}
+
+// waitForDomainAccess asks the platform to check the domain again and polls until its access is online
+func waitForDomainAccess(ctx context.Context, service *scdn.ScdnService, domainID int) error {
+	progressNames := make(map[string]string)
+	if resp, err := service.GetAccessProgress(); err != nil {
+		log.Printf("[WARN] Failed to get access progress options: %v", err)
+	} else {
+		for _, progress := range resp.Data.Progress {
+			progressNames[progress.Key] = progress.Name
+		}
+	}
+
+	progress := ""
+	for {
+		if _, err := service.RefreshDomainAccess(scdn.DomainAccessRefreshRequest{DomainIDs: []int{domainID}}); err != nil {
+			log.Printf("[WARN] Failed to refresh access of SCDN domain %d: %v", domainID, err)
+		}
+
+		resp, err := service.ListDomains(scdn.DomainListRequest{ID: domainID, Page: 1, PageSize: 10})
+		if err != nil {
+			log.Printf("[WARN] Failed to query SCDN domain %d: %v", domainID, err)
+		} else {
+			for _, info := range resp.Data.List {
+				if info.ID == domainID {
+					progress = info.AccessProgress
+				}
+			}
+			if progress == scdn.DomainAccessProgressOnline {
+				log.Printf("[INFO] SCDN domain %d access is online", domainID)
+				return nil
+			}
+			log.Printf("[DEBUG] Waiting for access of SCDN domain %d: %s", domainID, describeAccessProgress(progress, progressNames))
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout while waiting for access of SCDN domain %d, last progress: %s", domainID, describeAccessProgress(progress, progressNames))
+		case <-time.After(10 * time.Second):
+		}
+	}
+}
+
+func describeAccessProgress(key string, names map[string]string) string {
+	if name, ok := names[key]; ok && name != "" {
+		return fmt.Sprintf("%s (%s)", key, name)
+	}
+	return key
+}
@@ -1,30 +1,38 @@
 package domain
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn/domain_group"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // ResourceEdgenextScdnDomain returns the SCDN domain resource
 func ResourceEdgenextScdnDomain() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceScdnDomainCreate,
-		Read:   resourceScdnDomainRead,
-		Update: resourceScdnDomainUpdate,
-		Delete: resourceScdnDomainDelete,
+		CreateContext: resourceScdnDomainCreate,
+		Read:          resourceScdnDomainRead,
+		Update:        resourceScdnDomainUpdate,
+		Delete:        resourceScdnDomainDelete,
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:        schema.TypeString,
@@ -69,6 +77,39 @@ func ResourceEdgenextScdnDomain() *schema.Resource {
 				Optional:    true,
 				Description: "The recommended configuration. Valid values: large_file (large file download), web_acce (website acceleration). This parameter is mutually exclusive with tpl_id",
 			},
+			"verification_zone_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"verification_record"},
+				Description:  "The ID of the SDNS domain (zone) in which to create verification_record. When set, creating the domain waits until its access is online and then deletes the record, which requires the access CNAME of the domain to be in place already. Only used when creating the domain",
+			},
+			"verification_record": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				RequiredWith: []string{"verification_zone_id"},
+				Description:  "The DNS record the platform asks for to prove ownership of the domain. Only used when creating the domain",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The host name of the record, fully qualified or relative to the zone",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"TXT", "CNAME"}, true),
+							Description:  "The type of the record, TXT or CNAME",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The value of the record",
+						},
+					},
+				},
+			},
 			"origins": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -227,10 +268,15 @@ func ResourceEdgenextScdnDomain() *schema.Resource {
 	}
 }
 
-func resourceScdnDomainCreate(d *schema.ResourceData, m interface{}) error {
+func resourceScdnDomainCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	client := m.(*connectivity.EdgeNextClient)
 	service := scdn.NewScdnService(client)
 
+	challenge := expandVerificationChallenge(d)
+	if err := checkVerificationChallenge(challenge, d.Get("domain").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// Build create request
 	req := scdn.DomainCreateRequest{
 		Domain:              d.Get("domain").(string),
@@ -289,7 +335,7 @@ func resourceScdnDomainCreate(d *schema.ResourceData, m interface{}) error {
 	log.Printf("[INFO] Creating SCDN domain: %+v", req)
 	response, err := service.CreateDomain(req)
 	if err != nil {
-		return fmt.Errorf("failed to create SCDN domain: %w", err)
+		return diag.Errorf("failed to create SCDN domain: %s", err)
 	}
 
 	log.Printf("[DEBUG] Domain creation response: %+v", response)
@@ -310,8 +356,24 @@ func resourceScdnDomainCreate(d *schema.ResourceData, m interface{}) error {
 	}
 	log.Printf("[INFO] SCDN domain created successfully: %s", d.Id())
 
+	// The domain exists whatever the outcome of the verification, so a failure is only a warning
+	// and does not taint it
+	var diags diag.Diagnostics
+	if zoneID := d.Get("verification_zone_id").(int); zoneID != 0 && challenge.Name != "" {
+		if err := verifyDomainOwnership(ctx, client, response.Data.ID, zoneID, challenge); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Ownership verification of SCDN domain %s did not complete", response.Data.Domain),
+				Detail:   err.Error(),
+			})
+		}
+	}
+
 	// Still call read to get full details
-	return resourceScdnDomainRead(d, m)
+	if err := resourceScdnDomainRead(d, m); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	return diags
 }
 
 func resourceScdnDomainRead(d *schema.ResourceData, m interface{}) error {
@@ -416,6 +416,9 @@ type AccessProgressData struct {
 	Progress []ProgressInfo `json:"progress"`
 }
 
+// DomainAccessProgressOnline is the DomainInfo.AccessProgress of a domain whose access has completed
+const DomainAccessProgressOnline = "online"
+
 // ProgressInfo progress information
 type ProgressInfo struct {
 	Key  string `json:"key"`
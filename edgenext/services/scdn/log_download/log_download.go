@@ -10,6 +10,7 @@ import (
 func Resources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
 		"edgenext_scdn_log_download_task":            resource.ResourceEdgenextScdnLogDownloadTask(),
+		"edgenext_scdn_log_download_schedule":        resource.ResourceEdgenextScdnLogDownloadSchedule(),
 		"edgenext_scdn_log_download_template":        resource.ResourceEdgenextScdnLogDownloadTemplate(),
 		"edgenext_scdn_log_download_template_status": resource.ResourceEdgenextScdnLogDownloadTemplateStatus(),
 	}
@@ -0,0 +1,161 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/helper"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// scdnLogDownloadTimeLayout is the format of start_time and end_time
+	scdnLogDownloadTimeLayout = "2006-01-02 15:04:05"
+	// scdnLogDownloadMaxRange is the longest time range a single log download task may cover
+	scdnLogDownloadMaxRange = 31 * 24 * time.Hour
+)
+
+// validateScdnLogDownloadTimeRange checks that start_time is before end_time and that the
+// range does not exceed scdnLogDownloadMaxRange
+func validateScdnLogDownloadTimeRange(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	raw := d.GetRawConfig()
+	startValue := raw.GetAttr("start_time")
+	endValue := raw.GetAttr("end_time")
+	if !startValue.IsKnown() || startValue.IsNull() || !endValue.IsKnown() || endValue.IsNull() {
+		return nil
+	}
+	return checkScdnLogDownloadTimeRange(startValue.AsString(), endValue.AsString())
+}
+
+func checkScdnLogDownloadTimeRange(startTime, endTime string) error {
+	start, err := time.Parse(scdnLogDownloadTimeLayout, startTime)
+	if err != nil {
+		return fmt.Errorf("start_time %q must be in the format YYYY-MM-DD HH:MM:SS", startTime)
+	}
+	end, err := time.Parse(scdnLogDownloadTimeLayout, endTime)
+	if err != nil {
+		return fmt.Errorf("end_time %q must be in the format YYYY-MM-DD HH:MM:SS", endTime)
+	}
+	if !start.Before(end) {
+		return fmt.Errorf("start_time %q must be before end_time %q", startTime, endTime)
+	}
+	if end.Sub(start) > scdnLogDownloadMaxRange {
+		return fmt.Errorf("time range from %q to %q exceeds the maximum of %d days", startTime, endTime, int(scdnLogDownloadMaxRange.Hours()/24))
+	}
+	return nil
+}
+
+// validateScdnLogDownloadFields checks download_fields and search_terms keys against the field
+// catalogue of the configured data_source. Validation is skipped if the catalogue is unavailable.
+func validateScdnLogDownloadFields(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	raw := d.GetRawConfig()
+	dataSourceValue := raw.GetAttr("data_source")
+	if !dataSourceValue.IsKnown() || dataSourceValue.IsNull() {
+		return nil
+	}
+	dataSource := dataSourceValue.AsString()
+
+	client, ok := m.(*connectivity.EdgeNextClient)
+	if !ok || client == nil {
+		return nil
+	}
+	service := scdn.NewScdnService(client)
+	catalogue, err := service.GetLogDownloadFieldCatalogue()
+	if err != nil {
+		log.Printf("[WARN] Skipping SCDN log download field validation: %v", err)
+		return nil
+	}
+
+	config, ok := catalogue[dataSource]
+	if !ok {
+		dataSources := make([]string, 0, len(catalogue))
+		for key := range catalogue {
+			dataSources = append(dataSources, key)
+		}
+		sort.Strings(dataSources)
+		return fmt.Errorf("data_source %q is not supported, valid values are: %s%s",
+			dataSource, strings.Join(dataSources, ", "), didYouMean(dataSource, dataSources))
+	}
+
+	searchKeys := make([]string, 0)
+	searchTerms := raw.GetAttr("search_terms")
+	if searchTerms.IsKnown() && !searchTerms.IsNull() {
+		for it := searchTerms.ElementIterator(); it.Next(); {
+			_, term := it.Element()
+			if !term.IsKnown() || term.IsNull() {
+				searchKeys = append(searchKeys, "")
+				continue
+			}
+			searchKeys = append(searchKeys, knownString(term.GetAttr("key")))
+		}
+	}
+
+	errs := checkScdnLogDownloadFields(config, dataSource, knownStrings(raw.GetAttr("download_fields")), searchKeys)
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid log download configuration:\n  %s", strings.Join(errs, "\n  "))
+	}
+	return nil
+}
+
+// checkScdnLogDownloadFields returns an error message for every download field and search key that
+// is not in the catalogue of the data source. Empty values stand for unknown ones and are skipped.
+func checkScdnLogDownloadFields(config scdn.LogDownloadFieldConfig, dataSource string, downloadFields, searchKeys []string) []string {
+	var errs []string
+	for i, field := range downloadFields {
+		if field != "" && !containsString(config.DownloadFields, field) {
+			errs = append(errs, fmt.Sprintf("download_fields.%d: unknown field %q for data source %q%s",
+				i, field, dataSource, didYouMean(field, config.DownloadFields)))
+		}
+	}
+	for i, key := range searchKeys {
+		if key != "" && !containsString(config.SearchTerms, key) {
+			errs = append(errs, fmt.Sprintf("search_terms.%d.key: unknown search key %q for data source %q%s",
+				i, key, dataSource, didYouMean(key, config.SearchTerms)))
+		}
+	}
+	return errs
+}
+
+// knownStrings returns the elements of a list of strings, unknown elements are returned as ""
+func knownStrings(value cty.Value) []string {
+	if !value.IsKnown() || value.IsNull() {
+		return nil
+	}
+	result := make([]string, 0, value.LengthInt())
+	for it := value.ElementIterator(); it.Next(); {
+		_, v := it.Element()
+		result = append(result, knownString(v))
+	}
+	return result
+}
+
+// knownString returns a string value, or "" when it is unknown or null
+func knownString(value cty.Value) string {
+	if !value.IsKnown() || value.IsNull() {
+		return ""
+	}
+	return value.AsString()
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func didYouMean(value string, candidates []string) string {
+	if suggestion := helper.SuggestClosest(value, candidates); suggestion != "" {
+		return fmt.Sprintf(", did you mean %q?", suggestion)
+	}
+	return ""
+}
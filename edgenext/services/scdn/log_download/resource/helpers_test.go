@@ -0,0 +1,132 @@
+package resource
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
+)
+
+func TestCheckScdnLogDownloadTimeRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		startTime string
+		endTime   string
+		wantErr   string
+	}{
+		{
+			name:      "one day",
+			startTime: "2024-03-09 00:00:00",
+			endTime:   "2024-03-09 23:59:59",
+		},
+		{
+			name:      "exactly the maximum range",
+			startTime: "2024-03-01 00:00:00",
+			endTime:   "2024-04-01 00:00:00",
+		},
+		{
+			name:      "longer than the maximum range",
+			startTime: "2024-03-01 00:00:00",
+			endTime:   "2024-04-01 00:00:01",
+			wantErr:   "exceeds the maximum of 31 days",
+		},
+		{
+			name:      "start equal to end",
+			startTime: "2024-03-09 12:00:00",
+			endTime:   "2024-03-09 12:00:00",
+			wantErr:   "must be before end_time",
+		},
+		{
+			name:      "start after end",
+			startTime: "2024-03-10 00:00:00",
+			endTime:   "2024-03-09 00:00:00",
+			wantErr:   "must be before end_time",
+		},
+		{
+			name:      "invalid start_time",
+			startTime: "2024-03-09T00:00:00Z",
+			endTime:   "2024-03-09 23:59:59",
+			wantErr:   "start_time \"2024-03-09T00:00:00Z\" must be in the format",
+		},
+		{
+			name:      "invalid end_time",
+			startTime: "2024-03-09 00:00:00",
+			endTime:   "2024-03-09",
+			wantErr:   "end_time \"2024-03-09\" must be in the format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkScdnLogDownloadTimeRange(tt.startTime, tt.endTime)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkScdnLogDownloadTimeRange() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkScdnLogDownloadTimeRange() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckScdnLogDownloadFields(t *testing.T) {
+	config := scdn.LogDownloadFieldConfig{
+		Name:           "ng",
+		DownloadFields: []string{"time", "client_ip", "status", "request_uri"},
+		SearchTerms:    []string{"domain", "client_ip", "status"},
+	}
+
+	tests := []struct {
+		name           string
+		downloadFields []string
+		searchKeys     []string
+		want           []string
+	}{
+		{
+			name:           "known fields and keys",
+			downloadFields: []string{"time", "client_ip"},
+			searchKeys:     []string{"domain"},
+		},
+		{
+			name:           "unknown values are skipped",
+			downloadFields: []string{"", "status"},
+			searchKeys:     []string{""},
+		},
+		{
+			name:           "unknown download field with suggestion",
+			downloadFields: []string{"time", "client_iq"},
+			want: []string{
+				`download_fields.1: unknown field "client_iq" for data source "ng", did you mean "client_ip"?`,
+			},
+		},
+		{
+			name:       "unknown search key with suggestion",
+			searchKeys: []string{"domain", "stats"},
+			want: []string{
+				`search_terms.1.key: unknown search key "stats" for data source "ng", did you mean "status"?`,
+			},
+		},
+		{
+			name:           "download field that is only a search key elsewhere",
+			downloadFields: []string{"domain"},
+			searchKeys:     []string{"request_uri"},
+			want: []string{
+				`download_fields.0: unknown field "domain" for data source "ng"`,
+				`search_terms.0.key: unknown search key "request_uri" for data source "ng"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkScdnLogDownloadFields(config, "ng", tt.downloadFields, tt.searchKeys)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checkScdnLogDownloadFields() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
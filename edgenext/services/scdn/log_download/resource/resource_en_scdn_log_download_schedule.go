@@ -0,0 +1,439 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/helper"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	scdnLogDownloadWindowPreviousDay = "previous_day"
+	scdnLogDownloadWindowLastHours   = "last_hours"
+)
+
+var scdnLogDownloadUTCOffsetRegexp = regexp.MustCompile(`^[+-](0\d|1[0-4]):[0-5]\d$`)
+
+// ResourceEdgenextScdnLogDownloadSchedule returns the SCDN log download schedule resource
+func ResourceEdgenextScdnLogDownloadSchedule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceScdnLogDownloadScheduleCreate,
+		Read:   resourceScdnLogDownloadScheduleRead,
+		Update: resourceScdnLogDownloadScheduleUpdate,
+		Delete: resourceScdnLogDownloadScheduleDelete,
+
+		CustomizeDiff: resourceScdnLogDownloadScheduleCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"task_name_prefix": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Prefix of generated task names, the window start is appended to it",
+			},
+			"template_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Log download template ID used for generated tasks",
+			},
+			"window": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{scdnLogDownloadWindowPreviousDay, scdnLogDownloadWindowLastHours}, false),
+				Description:  "Time window of generated tasks: previous_day (the previous full day), last_hours (the last `hours` full hours)",
+			},
+			"hours": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntBetween(1, int(scdnLogDownloadMaxRange.Hours())),
+				Description:  "Number of full hours covered when window is last_hours, default: 1",
+			},
+			"utc_offset": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "+08:00",
+				ValidateFunc: validation.StringMatch(scdnLogDownloadUTCOffsetRegexp, "must be in the format +HH:MM or -HH:MM"),
+				Description:  "UTC offset the window boundaries are computed in, default: +08:00. The window is sent to the API in its own time zone (UTC+8)",
+			},
+			"data_source": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Data source: ng, cc, waf. Defaults to the template's data source",
+			},
+			"download_fields": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Download fields. Defaults to the template's download fields",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"file_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "csv",
+				ValidateFunc: validation.StringInSlice([]string{"xls", "csv", "json"}, false),
+				Description:  "File type: xls, csv, json, default: csv",
+			},
+			"lang": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "zh_CN",
+				Description: "Language: zh_CN, en_US, default: zh_CN",
+			},
+			"history_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntBetween(1, 1000),
+				Description:  "Maximum number of generated tasks kept in history, default: 30",
+			},
+			// Computed fields
+			"start_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Start time of the current window, in the API time zone (UTC+8)",
+			},
+			"end_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "End time of the current window, in the API time zone (UTC+8)",
+			},
+			"task_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the task for the current window",
+			},
+			"task_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the task for the current window",
+			},
+			"history": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Generated tasks, oldest first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"task_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Task ID",
+						},
+						"task_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Task name",
+						},
+						"start_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Window start time",
+						},
+						"end_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Window end time",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceScdnLogDownloadScheduleCustomizeDiff computes the current window at plan time so that
+// a new window shows up as a diff and triggers the creation of its task
+func resourceScdnLogDownloadScheduleCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("task_name_prefix") || !d.NewValueKnown("window") || !d.NewValueKnown("hours") || !d.NewValueKnown("utc_offset") {
+		return nil
+	}
+
+	start, end, err := computeScdnLogDownloadWindow(d.Get("window").(string), d.Get("hours").(int), d.Get("utc_offset").(string), time.Now())
+	if err != nil {
+		return err
+	}
+	if d.Get("start_time").(string) == start && d.Get("end_time").(string) == end {
+		// Same window, but an update may still generate a task or trim the history
+		if d.Id() != "" && d.HasChanges("template_id", "data_source", "download_fields", "file_type", "lang", "history_limit") {
+			for _, key := range []string{"task_id", "history"} {
+				if err := d.SetNewComputed(key); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	log.Printf("[DEBUG] SCDN log download schedule window changed to %s - %s", start, end)
+	if err := d.SetNew("start_time", start); err != nil {
+		return err
+	}
+	if err := d.SetNew("end_time", end); err != nil {
+		return err
+	}
+	if err := d.SetNew("task_name", scdnLogDownloadScheduleTaskName(d.Get("task_name_prefix").(string), start)); err != nil {
+		return err
+	}
+	for _, key := range []string{"task_id", "history"} {
+		if err := d.SetNewComputed(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceScdnLogDownloadScheduleCreate(d *schema.ResourceData, m interface{}) error {
+	d.SetId(d.Get("task_name_prefix").(string))
+	return resourceScdnLogDownloadScheduleApply(d, m)
+}
+
+func resourceScdnLogDownloadScheduleRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	// The schedule itself only exists in state. Check that the task of the current window still
+	// exists, so that a task deleted outside Terraform is generated again on the next apply.
+	taskID := d.Get("task_id").(int)
+	if taskID == 0 {
+		return nil
+	}
+	if _, err := service.GetLogDownloadTask(taskID, d.Get("task_name").(string)); err != nil {
+		if !errors.Is(err, scdn.ErrLogDownloadTaskNotFound) {
+			return fmt.Errorf("failed to read SCDN log download task %d: %w", taskID, err)
+		}
+		log.Printf("[WARN] Task %d of SCDN log download schedule %s not found, it will be generated again: %v", taskID, d.Id(), err)
+		for _, key := range []string{"start_time", "end_time", "task_name"} {
+			if err := d.Set(key, ""); err != nil {
+				log.Printf("[WARN] Failed to set %s: %v", key, err)
+			}
+		}
+		if err := d.Set("task_id", 0); err != nil {
+			log.Printf("[WARN] Failed to set task_id: %v", err)
+		}
+	}
+	return nil
+}
+
+func resourceScdnLogDownloadScheduleUpdate(d *schema.ResourceData, m interface{}) error {
+	return resourceScdnLogDownloadScheduleApply(d, m)
+}
+
+func resourceScdnLogDownloadScheduleDelete(d *schema.ResourceData, m interface{}) error {
+	// Generated tasks are kept, they are only forgotten together with the schedule
+	log.Printf("[INFO] Removing SCDN log download schedule %s from state, generated tasks are kept", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// resourceScdnLogDownloadScheduleApply creates the task of the current window unless a task for
+// that window already exists, and records it in history
+func resourceScdnLogDownloadScheduleApply(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	start := d.Get("start_time").(string)
+	end := d.Get("end_time").(string)
+	if start == "" || end == "" {
+		var err error
+		start, end, err = computeScdnLogDownloadWindow(d.Get("window").(string), d.Get("hours").(int), d.Get("utc_offset").(string), time.Now())
+		if err != nil {
+			return err
+		}
+	}
+	taskName := scdnLogDownloadScheduleTaskName(d.Get("task_name_prefix").(string), start)
+
+	taskID, err := findScdnLogDownloadTaskForWindow(service, taskName, start, end)
+	if err != nil {
+		return err
+	}
+
+	if taskID != 0 {
+		log.Printf("[INFO] SCDN log download task for window %s - %s already exists: task_id=%d", start, end, taskID)
+	} else {
+		req, err := buildScdnLogDownloadScheduleTaskRequest(d, service, taskName, start, end)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("[INFO] Creating SCDN log download task %s for window %s - %s", taskName, start, end)
+		response, err := service.AddLogDownloadTask(*req)
+		if err != nil {
+			return fmt.Errorf("failed to create SCDN log download task: %w", err)
+		}
+		taskID = response.Data.TaskID
+		if taskID == 0 {
+			// Same fallback as edgenext_scdn_log_download_task, look the task up by name
+			taskID, err = findScdnLogDownloadTaskForWindow(service, taskName, start, end)
+			if err != nil {
+				return err
+			}
+			if taskID == 0 {
+				return fmt.Errorf("failed to create log download task: API returned task_id=0 and task not found by name")
+			}
+		}
+	}
+
+	history := appendScdnLogDownloadScheduleHistory(d.Get("history").([]interface{}), map[string]interface{}{
+		"task_id":    taskID,
+		"task_name":  taskName,
+		"start_time": start,
+		"end_time":   end,
+	}, d.Get("history_limit").(int))
+
+	if err := d.Set("start_time", start); err != nil {
+		return fmt.Errorf("error setting start_time: %w", err)
+	}
+	if err := d.Set("end_time", end); err != nil {
+		return fmt.Errorf("error setting end_time: %w", err)
+	}
+	if err := d.Set("task_id", taskID); err != nil {
+		return fmt.Errorf("error setting task_id: %w", err)
+	}
+	if err := d.Set("task_name", taskName); err != nil {
+		return fmt.Errorf("error setting task_name: %w", err)
+	}
+	if err := d.Set("history", history); err != nil {
+		return fmt.Errorf("error setting history: %w", err)
+	}
+	return nil
+}
+
+// buildScdnLogDownloadScheduleTaskRequest builds the task request, falling back to the template
+// for data_source, download_fields and search terms
+func buildScdnLogDownloadScheduleTaskRequest(d *schema.ResourceData, service *scdn.ScdnService, taskName, start, end string) (*scdn.LogDownloadTaskAddRequest, error) {
+	templateID := d.Get("template_id").(int)
+	template, err := service.GetLogDownloadTemplate(templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SCDN log download template: %w", err)
+	}
+
+	req := &scdn.LogDownloadTaskAddRequest{
+		TaskName:       taskName,
+		IsUseTemplate:  1,
+		TemplateID:     templateID,
+		DataSource:     template.DataSource,
+		DownloadFields: template.DownloadFields,
+		SearchTerms:    make(map[string]string),
+		FileType:       d.Get("file_type").(string),
+		StartTime:      start,
+		EndTime:        end,
+		Lang:           d.Get("lang").(string),
+	}
+
+	if v, ok := d.GetOk("data_source"); ok {
+		req.DataSource = v.(string)
+	}
+	if v, ok := d.GetOk("download_fields"); ok {
+		fieldsList := v.([]interface{})
+		req.DownloadFields = make([]string, len(fieldsList))
+		for i, item := range fieldsList {
+			req.DownloadFields[i] = item.(string)
+		}
+	}
+	for _, term := range convertSearchTermsForTemplate(template.SearchTerms) {
+		req.SearchTerms[term["key"].(string)] = term["value"].(string)
+	}
+
+	return req, nil
+}
+
+// findScdnLogDownloadTaskForWindow returns the ID of a task with the given name and window that
+// has not failed or been cancelled, or 0 if there is none
+func findScdnLogDownloadTaskForWindow(service *scdn.ScdnService, taskName, start, end string) (int, error) {
+	response, err := service.ListLogDownloadTasks(scdn.LogDownloadTaskListRequest{
+		Page:     1,
+		PerPage:  100,
+		Status:   -1,
+		TaskName: taskName,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list log download tasks: %w", err)
+	}
+
+	for i := range response.Data.List {
+		task := &response.Data.List[i]
+		if task.TaskName != taskName || task.StartTime != start || task.EndTime != end {
+			continue
+		}
+		switch task.StatusString() {
+		case scdn.LogDownloadTaskStatusFailed, scdn.LogDownloadTaskStatusCancelled:
+			continue
+		}
+		return task.TaskID, nil
+	}
+	return 0, nil
+}
+
+// appendScdnLogDownloadScheduleHistory appends entry unless its task is already recorded and
+// keeps the newest limit entries
+func appendScdnLogDownloadScheduleHistory(history []interface{}, entry map[string]interface{}, limit int) []interface{} {
+	result := make([]interface{}, 0, len(history)+1)
+	for _, item := range history {
+		if item == nil {
+			continue
+		}
+		if item.(map[string]interface{})["task_id"].(int) == entry["task_id"].(int) {
+			continue
+		}
+		result = append(result, item)
+	}
+	result = append(result, entry)
+	if len(result) > limit {
+		result = result[len(result)-limit:]
+	}
+	return result
+}
+
+func scdnLogDownloadScheduleTaskName(prefix, start string) string {
+	t, err := time.Parse(scdnLogDownloadTimeLayout, start)
+	if err != nil {
+		return prefix
+	}
+	return prefix + "-" + t.Format("20060102-1504")
+}
+
+// computeScdnLogDownloadWindow returns the start and end time of the most recent full period
+// before now. Period boundaries follow the given UTC offset, the times are formatted in the API
+// time zone since the API takes them without an offset
+func computeScdnLogDownloadWindow(window string, hours int, utcOffset string, now time.Time) (string, string, error) {
+	if !scdnLogDownloadUTCOffsetRegexp.MatchString(utcOffset) {
+		return "", "", fmt.Errorf("utc_offset %q must be in the format +HH:MM or -HH:MM", utcOffset)
+	}
+	offsetHours, _ := strconv.Atoi(utcOffset[1:3])
+	offsetMinutes, _ := strconv.Atoi(utcOffset[4:6])
+	offset := offsetHours*3600 + offsetMinutes*60
+	if utcOffset[0] == '-' {
+		offset = -offset
+	}
+	local := now.In(time.FixedZone(utcOffset, offset))
+
+	var start, end time.Time
+	switch window {
+	case scdnLogDownloadWindowPreviousDay:
+		end = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+		start = end.AddDate(0, 0, -1)
+	case scdnLogDownloadWindowLastHours:
+		if hours < 1 {
+			return "", "", fmt.Errorf("hours must be at least 1")
+		}
+		end = time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), 0, 0, 0, local.Location())
+		start = end.Add(-time.Duration(hours) * time.Hour)
+	default:
+		return "", "", fmt.Errorf("unsupported window %q", window)
+	}
+
+	// end_time is inclusive, end one second before the next window starts
+	return start.In(helper.APILocation).Format(scdnLogDownloadTimeLayout),
+		end.Add(-time.Second).In(helper.APILocation).Format(scdnLogDownloadTimeLayout), nil
+}
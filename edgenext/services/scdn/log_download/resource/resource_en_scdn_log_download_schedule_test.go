@@ -0,0 +1,88 @@
+package resource
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeScdnLogDownloadWindow(t *testing.T) {
+	// 2024-03-10 01:30:00 UTC is 09:30 in UTC+8 and 2024-03-09 20:30 in UTC-05:00
+	now := time.Date(2024, 3, 10, 1, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		window    string
+		hours     int
+		utcOffset string
+		wantStart string
+		wantEnd   string
+	}{
+		{
+			name:      "previous day in the API time zone",
+			window:    scdnLogDownloadWindowPreviousDay,
+			hours:     1,
+			utcOffset: "+08:00",
+			wantStart: "2024-03-09 00:00:00",
+			wantEnd:   "2024-03-09 23:59:59",
+		},
+		{
+			name:      "previous day in UTC is converted to UTC+8",
+			window:    scdnLogDownloadWindowPreviousDay,
+			hours:     1,
+			utcOffset: "+00:00",
+			wantStart: "2024-03-09 08:00:00",
+			wantEnd:   "2024-03-10 07:59:59",
+		},
+		{
+			name:      "previous day west of UTC",
+			window:    scdnLogDownloadWindowPreviousDay,
+			hours:     1,
+			utcOffset: "-05:00",
+			wantStart: "2024-03-08 13:00:00",
+			wantEnd:   "2024-03-09 12:59:59",
+		},
+		{
+			name:      "last hours with a half hour offset",
+			window:    scdnLogDownloadWindowLastHours,
+			hours:     6,
+			utcOffset: "+05:30",
+			wantStart: "2024-03-10 03:30:00",
+			wantEnd:   "2024-03-10 09:29:59",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := computeScdnLogDownloadWindow(tt.window, tt.hours, tt.utcOffset, now)
+			if err != nil {
+				t.Fatalf("computeScdnLogDownloadWindow() error = %v", err)
+			}
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("computeScdnLogDownloadWindow() = %q - %q, want %q - %q", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestComputeScdnLogDownloadWindow_Errors(t *testing.T) {
+	now := time.Date(2024, 3, 10, 1, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		window    string
+		hours     int
+		utcOffset string
+	}{
+		{name: "invalid offset", window: scdnLogDownloadWindowPreviousDay, hours: 1, utcOffset: "8"},
+		{name: "zero hours", window: scdnLogDownloadWindowLastHours, hours: 0, utcOffset: "+08:00"},
+		{name: "unknown window", window: "next_day", hours: 1, utcOffset: "+08:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := computeScdnLogDownloadWindow(tt.window, tt.hours, tt.utcOffset, now); err == nil {
+				t.Errorf("computeScdnLogDownloadWindow() expected error")
+			}
+		})
+	}
+}
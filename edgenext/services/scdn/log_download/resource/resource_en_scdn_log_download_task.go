@@ -1,10 +1,17 @@
 package resource
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
 	"strconv"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -75,10 +82,17 @@ func ResourceEdgenextScdnLogDownloadTask() *schema.Resource {
 		Update: resourceScdnLogDownloadTaskUpdate,
 		Delete: resourceScdnLogDownloadTaskDelete,
 
+		CustomizeDiff: resourceScdnLogDownloadTaskCustomizeDiff,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"task_name": {
 				Type:        schema.TypeString,
@@ -148,6 +162,37 @@ func ResourceEdgenextScdnLogDownloadTask() *schema.Resource {
 				Default:     "zh_CN",
 				Description: "Language: zh_CN, en_US, default: zh_CN",
 			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to wait until the task is completed (status 2), bounded by the create/update timeout. Implied when output_path or oss_destination is set",
+			},
+			"output_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Local file path the completed log archive is written to",
+			},
+			"oss_destination": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "EdgeNext OSS object the completed log archive is uploaded to",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bucket": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "OSS bucket name",
+						},
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "OSS object key",
+						},
+					},
+				},
+			},
 			// Computed fields
 			"id": {
 				Type:        schema.TypeString,
@@ -169,6 +214,11 @@ func ResourceEdgenextScdnLogDownloadTask() *schema.Resource {
 				Computed:    true,
 				Description: "Download URL (available when task is completed)",
 			},
+			"archive_size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Size in bytes of the last archive fetched to output_path or oss_destination",
+			},
 			"created_at": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -183,6 +233,13 @@ func ResourceEdgenextScdnLogDownloadTask() *schema.Resource {
 	}
 }
 
+func resourceScdnLogDownloadTaskCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if err := validateScdnLogDownloadTimeRange(ctx, d, m); err != nil {
+		return err
+	}
+	return validateScdnLogDownloadFields(ctx, d, m)
+}
+
 func resourceScdnLogDownloadTaskCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*connectivity.EdgeNextClient)
 	service := scdn.NewScdnService(client)
@@ -336,6 +393,10 @@ func resourceScdnLogDownloadTaskCreate(d *schema.ResourceData, m interface{}) er
 
 	log.Printf("[INFO] SCDN log download task created successfully: %s", d.Id())
 
+	if err := resourceScdnLogDownloadTaskFetch(d, m, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
 	// Always call read to get full details from API
 	// This ensures all fields are properly set from the API response
 	return resourceScdnLogDownloadTaskRead(d, m)
@@ -528,45 +589,11 @@ func resourceScdnLogDownloadTaskRead(d *schema.ResourceData, m interface{}) erro
 		log.Printf("[WARN] Failed to set updated_at: %v", err)
 	}
 
-	// Status - convert to string if needed
-	if task.Status != nil {
-		var statusStr string
-		if statusStrVal, ok := task.Status.(string); ok {
-			statusStr = statusStrVal
-		} else if statusInt, ok := task.Status.(int); ok {
-			statusStr = fmt.Sprintf("%d", statusInt)
-		} else if statusFloat, ok := task.Status.(float64); ok {
-			statusStr = fmt.Sprintf("%.0f", statusFloat)
-		} else {
-			statusStr = fmt.Sprintf("%v", task.Status)
-		}
-		if err := d.Set("status", statusStr); err != nil {
-			log.Printf("[WARN] Failed to set status: %v", err)
-		}
-	} else {
-		// Set default status if nil
-		if err := d.Set("status", "0"); err != nil {
-			log.Printf("[WARN] Failed to set default status: %v", err)
-		}
+	if err := d.Set("status", task.StatusString()); err != nil {
+		log.Printf("[WARN] Failed to set status: %v", err)
 	}
-
-	// Download URL - optional field
-	if task.DownloadURL != nil {
-		if url, ok := task.DownloadURL.(string); ok && url != "" {
-			if err := d.Set("download_url", url); err != nil {
-				log.Printf("[WARN] Failed to set download_url: %v", err)
-			}
-		} else {
-			// Set empty string if download_url is null
-			if err := d.Set("download_url", ""); err != nil {
-				log.Printf("[WARN] Failed to set empty download_url: %v", err)
-			}
-		}
-	} else {
-		// Set empty string if download_url is nil
-		if err := d.Set("download_url", ""); err != nil {
-			log.Printf("[WARN] Failed to set empty download_url: %v", err)
-		}
+	if err := d.Set("download_url", task.DownloadURLString()); err != nil {
+		log.Printf("[WARN] Failed to set download_url: %v", err)
 	}
 
 	// Search terms - convert from map format to array format
@@ -587,6 +614,14 @@ func resourceScdnLogDownloadTaskRead(d *schema.ResourceData, m interface{}) erro
 }
 
 func resourceScdnLogDownloadTaskUpdate(d *schema.ResourceData, m interface{}) error {
+	// Archive destinations can change in place, fetch the archive again
+	if !d.HasChangesExcept("wait_for_completion", "output_path", "oss_destination") {
+		if err := resourceScdnLogDownloadTaskFetch(d, m, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+		return resourceScdnLogDownloadTaskRead(d, m)
+	}
+
 	// Log download tasks cannot be updated, only regenerated
 	// For now, we'll delete and recreate
 	return resourceScdnLogDownloadTaskDelete(d, m)
@@ -615,3 +650,144 @@ func resourceScdnLogDownloadTaskDelete(d *schema.ResourceData, m interface{}) er
 	d.SetId("")
 	return nil
 }
+
+// resourceScdnLogDownloadTaskFetch waits for the task to complete and copies the archive to
+// output_path and/or oss_destination. It is a no-op unless one of the fetch options is set.
+func resourceScdnLogDownloadTaskFetch(d *schema.ResourceData, m interface{}, timeout time.Duration) error {
+	outputPath := d.Get("output_path").(string)
+	ossDestination := d.Get("oss_destination").([]interface{})
+	if !d.Get("wait_for_completion").(bool) && outputPath == "" && len(ossDestination) == 0 {
+		return nil
+	}
+
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	taskID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid task ID: %w", err)
+	}
+	taskName := d.Get("task_name").(string)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	task, err := waitForScdnLogDownloadTask(ctx, service, taskID, taskName)
+	if err != nil {
+		return err
+	}
+	if outputPath == "" && len(ossDestination) == 0 {
+		return nil
+	}
+
+	// Download into a temporary file next to output_path so it can be renamed into place
+	// once complete, and re-read for the OSS upload without holding the archive in memory
+	tmpDir := os.TempDir()
+	if outputPath != "" {
+		tmpDir = filepath.Dir(outputPath)
+	}
+	tmpFile, err := os.CreateTemp(tmpDir, ".scdn-log-download-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	size, err := downloadScdnLogArchive(ctx, service, task, tmpFile)
+	if errors.Is(err, scdn.ErrLogDownloadLinkExpired) {
+		log.Printf("[INFO] Download link of SCDN log download task %d has expired, regenerating", taskID)
+		if _, err := service.RegenerateLogDownloadTask(scdn.LogDownloadTaskRegenerateRequest{TaskID: taskID}); err != nil {
+			return err
+		}
+		task, err = waitForScdnLogDownloadTask(ctx, service, taskID, taskName)
+		if err != nil {
+			return err
+		}
+		if _, err := tmpFile.Seek(0, 0); err != nil {
+			return fmt.Errorf("failed to rewind temporary file: %w", err)
+		}
+		if err := tmpFile.Truncate(0); err != nil {
+			return fmt.Errorf("failed to truncate temporary file: %w", err)
+		}
+		size, err = downloadScdnLogArchive(ctx, service, task, tmpFile)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to download SCDN log download task %d: %w", taskID, err)
+	}
+
+	if len(ossDestination) > 0 && ossDestination[0] != nil {
+		dest := ossDestination[0].(map[string]interface{})
+		bucket := dest["bucket"].(string)
+		key := dest["key"].(string)
+
+		ossClient, err := client.OSSClient()
+		if err != nil {
+			return err
+		}
+		if _, err := tmpFile.Seek(0, 0); err != nil {
+			return fmt.Errorf("failed to rewind temporary file: %w", err)
+		}
+
+		log.Printf("[INFO] Uploading SCDN log archive of task %d to OSS %s/%s (%d bytes)", taskID, bucket, key, size)
+		err = ossClient.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:        aws.String(bucket),
+			Key:           aws.String(key),
+			Body:          tmpFile,
+			ContentLength: aws.Int64(size),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to upload log archive to OSS %s/%s: %w", bucket, key, err)
+		}
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+	if outputPath != "" {
+		if err := os.Rename(tmpFile.Name(), outputPath); err != nil {
+			return fmt.Errorf("failed to write log archive to %s: %w", outputPath, err)
+		}
+		log.Printf("[INFO] SCDN log archive of task %d written to %s (%d bytes)", taskID, outputPath, size)
+	}
+
+	if err := d.Set("archive_size", int(size)); err != nil {
+		log.Printf("[WARN] Failed to set archive_size: %v", err)
+	}
+	return nil
+}
+
+// waitForScdnLogDownloadTask polls the task until it is completed, failed or the context expires
+func waitForScdnLogDownloadTask(ctx context.Context, service *scdn.ScdnService, taskID int, taskName string) (*scdn.LogDownloadTaskInfo, error) {
+	for {
+		task, err := service.GetLogDownloadTask(taskID, taskName)
+		if err != nil {
+			log.Printf("[WARN] Failed to query SCDN log download task %d: %v", taskID, err)
+		} else {
+			switch task.StatusString() {
+			case scdn.LogDownloadTaskStatusCompleted:
+				return task, nil
+			case scdn.LogDownloadTaskStatusFailed:
+				return nil, fmt.Errorf("SCDN log download task %d failed", taskID)
+			case scdn.LogDownloadTaskStatusCancelled:
+				return nil, fmt.Errorf("SCDN log download task %d was cancelled", taskID)
+			}
+			log.Printf("[DEBUG] Waiting for SCDN log download task %d: status=%s", taskID, task.StatusString())
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timeout while waiting for SCDN log download task %d to complete", taskID)
+		case <-time.After(10 * time.Second):
+		}
+	}
+}
+
+// downloadScdnLogArchive downloads a completed task's archive, a completed task without a
+// download URL is treated as expired so that it gets regenerated
+func downloadScdnLogArchive(ctx context.Context, service *scdn.ScdnService, task *scdn.LogDownloadTaskInfo, f *os.File) (int64, error) {
+	downloadURL := task.DownloadURLString()
+	if downloadURL == "" {
+		return 0, scdn.ErrLogDownloadLinkExpired
+	}
+	return service.DownloadLogArchive(ctx, downloadURL, f)
+}
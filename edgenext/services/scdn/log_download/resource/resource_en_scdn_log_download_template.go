@@ -75,6 +75,8 @@ func ResourceEdgenextScdnLogDownloadTemplate() *schema.Resource {
 		Update: resourceScdnLogDownloadTemplateUpdate,
 		Delete: resourceScdnLogDownloadTemplateDelete,
 
+		CustomizeDiff: validateScdnLogDownloadFields,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
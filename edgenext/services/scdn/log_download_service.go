@@ -3,9 +3,13 @@ package scdn
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"strconv"
+	"sync"
 
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
 )
@@ -214,6 +218,87 @@ func (s *ScdnService) RegenerateLogDownloadTask(req LogDownloadTaskRegenerateReq
 	return &response, nil
 }
 
+// ErrLogDownloadLinkExpired is returned by DownloadLogArchive when the download URL is no longer valid
+var ErrLogDownloadLinkExpired = errors.New("log download link has expired")
+
+// ErrLogDownloadTaskNotFound is returned by GetLogDownloadTask when no task has the given ID
+var ErrLogDownloadTaskNotFound = errors.New("log download task not found")
+
+// GetLogDownloadTask finds a single log download task by ID, taskName narrows the query when known
+func (s *ScdnService) GetLogDownloadTask(taskID int, taskName string) (*LogDownloadTaskInfo, error) {
+	req := LogDownloadTaskListRequest{
+		Status:   -1,
+		TaskName: taskName,
+		PerPage:  100,
+	}
+	for page := 1; page <= 5; page++ {
+		req.Page = page
+		response, err := s.ListLogDownloadTasks(req)
+		if err != nil {
+			return nil, err
+		}
+		for i := range response.Data.List {
+			if response.Data.List[i].TaskID == taskID {
+				return &response.Data.List[i], nil
+			}
+		}
+		if len(response.Data.List) < req.PerPage {
+			break
+		}
+	}
+	return nil, fmt.Errorf("%w: task_id=%d", ErrLogDownloadTaskNotFound, taskID)
+}
+
+// GetLogDownloadTemplate finds a single log download template by ID
+func (s *ScdnService) GetLogDownloadTemplate(templateID int) (*LogDownloadTemplateInfo, error) {
+	req := LogDownloadTemplateListRequest{
+		PerPage: 100,
+	}
+	for page := 1; page <= 5; page++ {
+		req.Page = page
+		response, err := s.ListLogDownloadTemplates(req)
+		if err != nil {
+			return nil, err
+		}
+		for i := range response.Data.List {
+			if response.Data.List[i].TemplateID == templateID {
+				return &response.Data.List[i], nil
+			}
+		}
+		if len(response.Data.List) < req.PerPage {
+			break
+		}
+	}
+	return nil, fmt.Errorf("log download template %d not found", templateID)
+}
+
+// DownloadLogArchive streams the archive behind a completed task's download URL into w
+func (s *ScdnService) DownloadLogArchive(ctx context.Context, downloadURL string, w io.Writer) (int64, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create download request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("failed to download log archive: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return 0, ErrLogDownloadLinkExpired
+	case resp.StatusCode != http.StatusOK:
+		return 0, fmt.Errorf("failed to download log archive: HTTP %d", resp.StatusCode)
+	}
+
+	written, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return written, fmt.Errorf("failed to read log archive: %w", err)
+	}
+	return written, nil
+}
+
 // GetLogDownloadFields gets log download fields
 func (s *ScdnService) GetLogDownloadFields() (*LogDownloadFieldsResponse, error) {
 	ctx := context.Background()
@@ -260,6 +345,29 @@ func (s *ScdnService) GetLogDownloadFields() (*LogDownloadFieldsResponse, error)
 	return response, nil
 }
 
+var (
+	logDownloadFieldCatalogueMu sync.Mutex
+	logDownloadFieldCatalogues  = map[*connectivity.EdgeNextClient]map[string]LogDownloadFieldConfig{}
+)
+
+// GetLogDownloadFieldCatalogue returns the log download field catalogue keyed by data source.
+// The catalogue is fetched once per client, i.e. once per provider run; failures are not cached.
+func (s *ScdnService) GetLogDownloadFieldCatalogue() (map[string]LogDownloadFieldConfig, error) {
+	logDownloadFieldCatalogueMu.Lock()
+	defer logDownloadFieldCatalogueMu.Unlock()
+
+	if catalogue, ok := logDownloadFieldCatalogues[s.client]; ok {
+		return catalogue, nil
+	}
+
+	response, err := s.GetLogDownloadFields()
+	if err != nil {
+		return nil, err
+	}
+	logDownloadFieldCatalogues[s.client] = response.Data
+	return response.Data, nil
+}
+
 // ListLogDownloadTemplates lists log download templates
 func (s *ScdnService) ListLogDownloadTemplates(req LogDownloadTemplateListRequest) (*LogDownloadTemplateListResponse, error) {
 	ctx := context.Background()
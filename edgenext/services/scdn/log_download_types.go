@@ -1,5 +1,7 @@
 package scdn
 
+import "fmt"
+
 // ============================================================================
 // Log Download Management Types
 // ============================================================================
@@ -26,6 +28,15 @@ type LogDownloadTaskListData struct {
 	List  []LogDownloadTaskInfo `json:"list"`
 }
 
+// Log download task status values as returned by the API
+const (
+	LogDownloadTaskStatusNotStarted = "0" // Not started
+	LogDownloadTaskStatusRunning    = "1" // In progress
+	LogDownloadTaskStatusCompleted  = "2" // Completed, download_url is available
+	LogDownloadTaskStatusFailed     = "3" // Failed
+	LogDownloadTaskStatusCancelled  = "4" // Cancelled
+)
+
 // LogDownloadTaskInfo log download task information
 type LogDownloadTaskInfo struct {
 	MemberID         string      `json:"member_id"`
@@ -65,6 +76,30 @@ type LogDownloadSearchTerm struct {
 	Value string `json:"value"`
 }
 
+// StatusString returns the task status as a string, the API returns it either as a string or a number
+func (t *LogDownloadTaskInfo) StatusString() string {
+	switch v := t.Status.(type) {
+	case nil:
+		return LogDownloadTaskStatusNotStarted
+	case string:
+		return v
+	case int:
+		return fmt.Sprintf("%d", v)
+	case float64:
+		return fmt.Sprintf("%.0f", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// DownloadURLString returns the download URL, or an empty string if the archive is not available
+func (t *LogDownloadTaskInfo) DownloadURLString() string {
+	if url, ok := t.DownloadURL.(string); ok {
+		return url
+	}
+	return ""
+}
+
 // LogDownloadTaskAddRequest log download task add request
 type LogDownloadTaskAddRequest struct {
 	TaskName       string            `json:"task_name"`             // Task name
@@ -10,6 +10,7 @@ func Resources() map[string]*schema.Resource {
 		"edgenext_scdn_network_speed_config":     ResourceEdgenextScdnNetworkSpeedConfig(),
 		"edgenext_scdn_network_speed_rule":       ResourceEdgenextScdnNetworkSpeedRule(),
 		"edgenext_scdn_network_speed_rules_sort": ResourceEdgenextScdnNetworkSpeedRulesSort(),
+		"edgenext_scdn_network_speed_rules":      ResourceEdgenextScdnNetworkSpeedRules(),
 	}
 }
 
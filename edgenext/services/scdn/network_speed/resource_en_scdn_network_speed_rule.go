@@ -13,6 +13,7 @@ import (
 
 // ResourceEdgenextScdnNetworkSpeedRule returns the SCDN network speed rule resource
 func ResourceEdgenextScdnNetworkSpeedRule() *schema.Resource {
+	blockSchemas := networkSpeedRuleBlockSchemas()
 	return &schema.Resource{
 		Create: resourceScdnNetworkSpeedRuleCreate,
 		Read:   resourceScdnNetworkSpeedRuleRead,
@@ -56,111 +57,10 @@ func ResourceEdgenextScdnNetworkSpeedRule() *schema.Resource {
 				},
 			},
 			// Rule types - only one should be set based on config_group
-			"custom_page": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				MaxItems:    1,
-				Description: "Custom page rule",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"status_code": {
-							Type:        schema.TypeInt,
-							Required:    true,
-							Description: "Status code",
-						},
-						"page_type": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Page type",
-						},
-						"page_content": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Page content",
-						},
-					},
-				},
-			},
-			"upstream_uri_change_rule": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				MaxItems:    1,
-				Description: "Upstream URI change rule",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"typ": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Type",
-						},
-						"action": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Action",
-						},
-						"match": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Match value",
-						},
-						"target": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Target value",
-						},
-					},
-				},
-			},
-			"resp_headers_rule": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				MaxItems:    1,
-				Description: "Response headers rule",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"type": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Type",
-						},
-						"content": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Content",
-						},
-						"remark": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Remark",
-						},
-					},
-				},
-			},
-			"customized_req_headers_rule": {
-				Type:        schema.TypeList,
-				Optional:    true,
-				MaxItems:    1,
-				Description: "Customized request headers rule",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"type": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Type",
-						},
-						"content": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "Content",
-						},
-						"remark": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Remark",
-						},
-					},
-				},
-			},
+			"custom_page":                 blockSchemas["custom_page"],
+			"upstream_uri_change_rule":    blockSchemas["upstream_uri_change_rule"],
+			"resp_headers_rule":           blockSchemas["resp_headers_rule"],
+			"customized_req_headers_rule": blockSchemas["customized_req_headers_rule"],
 			// Computed fields
 			"id": {
 				Type:        schema.TypeString,
@@ -561,3 +461,114 @@ func resourceScdnNetworkSpeedRuleDelete(d *schema.ResourceData, m interface{}) e
 	log.Printf("[INFO] Network speed rule deleted successfully")
 	return nil
 }
+
+// networkSpeedRuleBlockSchemas returns the schemas of the rule blocks, keyed by config_group
+func networkSpeedRuleBlockSchemas() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"custom_page": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Custom page rule",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"status_code": {
+						Type:        schema.TypeInt,
+						Required:    true,
+						Description: "Status code",
+					},
+					"page_type": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Page type",
+					},
+					"page_content": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Page content",
+					},
+				},
+			},
+		},
+		"upstream_uri_change_rule": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Upstream URI change rule",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"typ": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Type",
+					},
+					"action": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Action",
+					},
+					"match": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Match value",
+					},
+					"target": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Target value",
+					},
+				},
+			},
+		},
+		"resp_headers_rule": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Response headers rule",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Type",
+					},
+					"content": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Content",
+					},
+					"remark": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Remark",
+					},
+				},
+			},
+		},
+		"customized_req_headers_rule": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Customized request headers rule",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Type",
+					},
+					"content": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Content",
+					},
+					"remark": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Remark",
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,447 @@
+package networkspeed
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// networkSpeedConfigGroups lists the rule groups that hold ordered rules
+var networkSpeedConfigGroups = []string{"custom_page", "upstream_uri_change_rule", "resp_headers_rule", "customized_req_headers_rule"}
+
+// ResourceEdgenextScdnNetworkSpeedRules returns the SCDN network speed rules resource.
+// It manages the complete, ordered rule list of one rule group.
+func ResourceEdgenextScdnNetworkSpeedRules() *schema.Resource {
+	ruleSchema := networkSpeedRuleBlockSchemas()
+	ruleSchema["rule_id"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Rule ID",
+	}
+
+	return &schema.Resource{
+		Create: resourceScdnNetworkSpeedRulesCreate,
+		Read:   resourceScdnNetworkSpeedRulesRead,
+		Update: resourceScdnNetworkSpeedRulesUpdate,
+		Delete: resourceScdnNetworkSpeedRulesDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"business_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Business ID (template ID for 'tpl' type, user ID for 'global' type)",
+			},
+			"business_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Business type: 'tpl' (template) or 'global'",
+			},
+			"config_group": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(networkSpeedConfigGroups, false),
+				Description:  "Rule group: 'custom_page', 'upstream_uri_change_rule', 'resp_headers_rule', or 'customized_req_headers_rule'",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Ordered rules of the group. Each rule sets the block named by `config_group`. Rules not listed here are deleted, and the list order is applied as the rule priority.",
+				Elem: &schema.Resource{
+					Schema: ruleSchema,
+				},
+			},
+			// Computed fields
+			"rule_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Rule IDs in priority order",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+	}
+}
+
+func resourceScdnNetworkSpeedRulesCreate(d *schema.ResourceData, m interface{}) error {
+	businessID := d.Get("business_id").(int)
+	businessType := d.Get("business_type").(string)
+	configGroup := d.Get("config_group").(string)
+
+	d.SetId(fmt.Sprintf("%d-%s-%s", businessID, businessType, configGroup))
+
+	if err := resourceScdnNetworkSpeedRulesApply(d, m); err != nil {
+		return err
+	}
+
+	return resourceScdnNetworkSpeedRulesRead(d, m)
+}
+
+func resourceScdnNetworkSpeedRulesRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	businessID, businessType, configGroup, err := parseScdnNetworkSpeedRulesID(d)
+	if err != nil {
+		return err
+	}
+
+	req := scdn.NetworkSpeedGetRulesRequest{
+		BusinessID:   businessID,
+		BusinessType: businessType,
+		ConfigGroup:  configGroup,
+	}
+
+	log.Printf("[INFO] Reading SCDN network speed rules: business_id=%d, business_type=%s, config_group=%s", businessID, businessType, configGroup)
+	response, err := service.GetNetworkSpeedRules(req)
+	if err != nil {
+		return fmt.Errorf("failed to read network speed rules: %w", err)
+	}
+
+	// Every rule of the group is reported, so rules created outside Terraform show up as drift
+	ruleList := make([]interface{}, 0, len(response.Data.List))
+	ruleIDs := make([]int, 0, len(response.Data.List))
+	for _, rule := range response.Data.List {
+		ruleMap := flattenNetworkSpeedRule(rule)
+		ruleMap["rule_id"] = rule.ID
+		ruleList = append(ruleList, ruleMap)
+		ruleIDs = append(ruleIDs, rule.ID)
+	}
+
+	d.SetId(fmt.Sprintf("%d-%s-%s", businessID, businessType, configGroup))
+
+	if err := d.Set("business_id", businessID); err != nil {
+		log.Printf("[WARN] Failed to set business_id: %v", err)
+	}
+	if err := d.Set("business_type", businessType); err != nil {
+		log.Printf("[WARN] Failed to set business_type: %v", err)
+	}
+	if err := d.Set("config_group", configGroup); err != nil {
+		log.Printf("[WARN] Failed to set config_group: %v", err)
+	}
+	if err := d.Set("rule", ruleList); err != nil {
+		return fmt.Errorf("error setting rule: %w", err)
+	}
+	if err := d.Set("rule_ids", ruleIDs); err != nil {
+		log.Printf("[WARN] Failed to set rule_ids: %v", err)
+	}
+
+	log.Printf("[INFO] SCDN network speed rules read successfully: rule_ids=%v", ruleIDs)
+	return nil
+}
+
+func resourceScdnNetworkSpeedRulesUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("rule") {
+		if err := resourceScdnNetworkSpeedRulesApply(d, m); err != nil {
+			return err
+		}
+	}
+
+	return resourceScdnNetworkSpeedRulesRead(d, m)
+}
+
+func resourceScdnNetworkSpeedRulesDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	businessID, businessType, configGroup, err := parseScdnNetworkSpeedRulesID(d)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int, 0)
+	for _, v := range d.Get("rule_ids").([]interface{}) {
+		if id, ok := v.(int); ok && id > 0 {
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) > 0 {
+		req := scdn.NetworkSpeedDeleteRuleRequest{
+			BusinessID:   businessID,
+			BusinessType: businessType,
+			ConfigGroup:  configGroup,
+			IDs:          ids,
+		}
+
+		log.Printf("[INFO] Deleting SCDN network speed rules: config_group=%s, ids=%v", configGroup, ids)
+		if _, err := service.DeleteNetworkSpeedRule(req); err != nil {
+			return fmt.Errorf("failed to delete network speed rules: %w", err)
+		}
+	}
+
+	d.SetId("")
+	log.Printf("[INFO] SCDN network speed rules deleted successfully")
+	return nil
+}
+
+// resourceScdnNetworkSpeedRulesApply makes the API rule list match the configuration.
+// Network speed rules have no name, so existing rules with identical content are kept,
+// the remaining existing rules are updated in order, extra rules are created or deleted,
+// and the list is sorted once at the end.
+func resourceScdnNetworkSpeedRulesApply(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	businessID := d.Get("business_id").(int)
+	businessType := d.Get("business_type").(string)
+	configGroup := d.Get("config_group").(string)
+
+	desired := make([]scdn.NetworkSpeedRuleInfo, 0)
+	for i, item := range d.Get("rule").([]interface{}) {
+		itemMap, _ := item.(map[string]interface{})
+		rule := expandNetworkSpeedRule(itemMap)
+		if err := checkNetworkSpeedRuleGroup(rule, configGroup); err != nil {
+			return fmt.Errorf("rule %d: %w", i, err)
+		}
+		desired = append(desired, rule)
+	}
+
+	response, err := service.GetNetworkSpeedRules(scdn.NetworkSpeedGetRulesRequest{
+		BusinessID:   businessID,
+		BusinessType: businessType,
+		ConfigGroup:  configGroup,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read network speed rules: %w", err)
+	}
+	existing := response.Data.List
+
+	// First pass: keep existing rules whose content already matches
+	ids := make([]int, len(desired))
+	claimed := make([]bool, len(existing))
+	for i, rule := range desired {
+		for j, current := range existing {
+			if !claimed[j] && networkSpeedRulesEqual(rule, current) {
+				ids[i] = current.ID
+				claimed[j] = true
+				break
+			}
+		}
+	}
+
+	// Second pass: reuse the remaining existing rules in order, creating rules when none are left
+	next := 0
+	for i, rule := range desired {
+		if ids[i] != 0 {
+			continue
+		}
+		for next < len(existing) && claimed[next] {
+			next++
+		}
+
+		if next < len(existing) {
+			req := scdn.NetworkSpeedUpdateRuleRequest{
+				ID:                       existing[next].ID,
+				ConfigGroup:              configGroup,
+				CustomPage:               rule.CustomPage,
+				UpstreamURIChangeRule:    rule.UpstreamURIChangeRule,
+				RespHeadersRule:          rule.RespHeadersRule,
+				CustomizedReqHeadersRule: rule.CustomizedReqHeadersRule,
+			}
+
+			log.Printf("[INFO] Updating SCDN network speed rule: rule_id=%d", req.ID)
+			if _, err := service.UpdateNetworkSpeedRule(req); err != nil {
+				return fmt.Errorf("failed to update network speed rule %d: %w", req.ID, err)
+			}
+			ids[i] = req.ID
+			claimed[next] = true
+			continue
+		}
+
+		req := scdn.NetworkSpeedCreateRuleRequest{
+			BusinessID:               businessID,
+			BusinessType:             businessType,
+			ConfigGroup:              configGroup,
+			CustomPage:               rule.CustomPage,
+			UpstreamURIChangeRule:    rule.UpstreamURIChangeRule,
+			RespHeadersRule:          rule.RespHeadersRule,
+			CustomizedReqHeadersRule: rule.CustomizedReqHeadersRule,
+		}
+
+		log.Printf("[INFO] Creating SCDN network speed rule: config_group=%s", configGroup)
+		createResp, err := service.CreateNetworkSpeedRule(req)
+		if err != nil {
+			return fmt.Errorf("failed to create network speed rule %d: %w", i, err)
+		}
+		ids[i] = createResp.Data.ID
+	}
+
+	var deleteIDs []int
+	for j, current := range existing {
+		if !claimed[j] {
+			deleteIDs = append(deleteIDs, current.ID)
+		}
+	}
+	if len(deleteIDs) > 0 {
+		log.Printf("[INFO] Deleting SCDN network speed rules not in configuration: ids=%v", deleteIDs)
+		_, err := service.DeleteNetworkSpeedRule(scdn.NetworkSpeedDeleteRuleRequest{
+			BusinessID:   businessID,
+			BusinessType: businessType,
+			ConfigGroup:  configGroup,
+			IDs:          deleteIDs,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete network speed rules: %w", err)
+		}
+	}
+
+	if len(ids) > 0 {
+		req := scdn.NetworkSpeedSortRulesRequest{
+			BusinessID:   businessID,
+			BusinessType: businessType,
+			ConfigGroup:  configGroup,
+			IDs:          ids,
+		}
+
+		log.Printf("[INFO] Sorting SCDN network speed rules: config_group=%s, ids=%v", configGroup, ids)
+		if _, err := service.SortNetworkSpeedRules(req); err != nil {
+			return fmt.Errorf("failed to sort network speed rules: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// expandNetworkSpeedRule converts one rule block into the API rule payload
+func expandNetworkSpeedRule(ruleMap map[string]interface{}) scdn.NetworkSpeedRuleInfo {
+	var rule scdn.NetworkSpeedRuleInfo
+
+	if list, ok := ruleMap["custom_page"].([]interface{}); ok && len(list) > 0 && list[0] != nil {
+		customPageMap := list[0].(map[string]interface{})
+		rule.CustomPage = &scdn.CustomPageRule{
+			StatusCode:  customPageMap["status_code"].(int),
+			PageType:    customPageMap["page_type"].(string),
+			PageContent: customPageMap["page_content"].(string),
+		}
+	}
+	if list, ok := ruleMap["upstream_uri_change_rule"].([]interface{}); ok && len(list) > 0 && list[0] != nil {
+		uriChangeMap := list[0].(map[string]interface{})
+		rule.UpstreamURIChangeRule = &scdn.UpstreamURIChangeRule{
+			Type:   uriChangeMap["typ"].(string),
+			Action: uriChangeMap["action"].(string),
+			Match:  uriChangeMap["match"].(string),
+			Target: uriChangeMap["target"].(string),
+		}
+	}
+	if list, ok := ruleMap["resp_headers_rule"].([]interface{}); ok && len(list) > 0 && list[0] != nil {
+		respHeadersMap := list[0].(map[string]interface{})
+		rule.RespHeadersRule = &scdn.RespHeadersRule{
+			Type:    respHeadersMap["type"].(string),
+			Content: respHeadersMap["content"].(string),
+			Remark:  respHeadersMap["remark"].(string),
+		}
+	}
+	if list, ok := ruleMap["customized_req_headers_rule"].([]interface{}); ok && len(list) > 0 && list[0] != nil {
+		reqHeadersMap := list[0].(map[string]interface{})
+		rule.CustomizedReqHeadersRule = &scdn.CustomizedReqHeadersRule{
+			Type:    reqHeadersMap["type"].(string),
+			Content: reqHeadersMap["content"].(string),
+			Remark:  reqHeadersMap["remark"].(string),
+		}
+	}
+
+	return rule
+}
+
+// flattenNetworkSpeedRule converts an API rule into a rule block
+func flattenNetworkSpeedRule(rule scdn.NetworkSpeedRuleInfo) map[string]interface{} {
+	ruleMap := map[string]interface{}{
+		"custom_page":                 []interface{}{},
+		"upstream_uri_change_rule":    []interface{}{},
+		"resp_headers_rule":           []interface{}{},
+		"customized_req_headers_rule": []interface{}{},
+	}
+
+	if rule.CustomPage != nil {
+		ruleMap["custom_page"] = []interface{}{map[string]interface{}{
+			"status_code":  rule.CustomPage.StatusCode,
+			"page_type":    rule.CustomPage.PageType,
+			"page_content": rule.CustomPage.PageContent,
+		}}
+	}
+	if rule.UpstreamURIChangeRule != nil {
+		ruleMap["upstream_uri_change_rule"] = []interface{}{map[string]interface{}{
+			"typ":    rule.UpstreamURIChangeRule.Type,
+			"action": rule.UpstreamURIChangeRule.Action,
+			"match":  rule.UpstreamURIChangeRule.Match,
+			"target": rule.UpstreamURIChangeRule.Target,
+		}}
+	}
+	if rule.RespHeadersRule != nil {
+		ruleMap["resp_headers_rule"] = []interface{}{map[string]interface{}{
+			"type":    rule.RespHeadersRule.Type,
+			"content": rule.RespHeadersRule.Content,
+			"remark":  rule.RespHeadersRule.Remark,
+		}}
+	}
+	if rule.CustomizedReqHeadersRule != nil {
+		ruleMap["customized_req_headers_rule"] = []interface{}{map[string]interface{}{
+			"type":    rule.CustomizedReqHeadersRule.Type,
+			"content": rule.CustomizedReqHeadersRule.Content,
+			"remark":  rule.CustomizedReqHeadersRule.Remark,
+		}}
+	}
+
+	return ruleMap
+}
+
+// checkNetworkSpeedRuleGroup ensures a rule sets exactly the block of its config_group
+func checkNetworkSpeedRuleGroup(rule scdn.NetworkSpeedRuleInfo, configGroup string) error {
+	set := map[string]bool{
+		"custom_page":                 rule.CustomPage != nil,
+		"upstream_uri_change_rule":    rule.UpstreamURIChangeRule != nil,
+		"resp_headers_rule":           rule.RespHeadersRule != nil,
+		"customized_req_headers_rule": rule.CustomizedReqHeadersRule != nil,
+	}
+	if !set[configGroup] {
+		return fmt.Errorf("the %s block is required for config_group %q", configGroup, configGroup)
+	}
+	for group, ok := range set {
+		if ok && group != configGroup {
+			return fmt.Errorf("the %s block cannot be used with config_group %q", group, configGroup)
+		}
+	}
+	return nil
+}
+
+// networkSpeedRulesEqual reports whether two rules carry the same content
+func networkSpeedRulesEqual(a, b scdn.NetworkSpeedRuleInfo) bool {
+	return reflect.DeepEqual(a.CustomPage, b.CustomPage) &&
+		reflect.DeepEqual(a.UpstreamURIChangeRule, b.UpstreamURIChangeRule) &&
+		reflect.DeepEqual(a.RespHeadersRule, b.RespHeadersRule) &&
+		reflect.DeepEqual(a.CustomizedReqHeadersRule, b.CustomizedReqHeadersRule)
+}
+
+// parseScdnNetworkSpeedRulesID parses the business_id-business_type-config_group resource ID
+func parseScdnNetworkSpeedRulesID(d *schema.ResourceData) (int, string, string, error) {
+	parts := strings.SplitN(d.Id(), "-", 3)
+	if len(parts) == 3 {
+		businessID, err := strconv.Atoi(parts[0])
+		if err == nil && parts[1] != "" && parts[2] != "" {
+			return businessID, parts[1], parts[2], nil
+		}
+	}
+
+	businessID := d.Get("business_id").(int)
+	businessType := d.Get("business_type").(string)
+	configGroup := d.Get("config_group").(string)
+	if businessID == 0 || businessType == "" || configGroup == "" {
+		return 0, "", "", fmt.Errorf("invalid resource ID %q, expected business_id-business_type-config_group", d.Id())
+	}
+	return businessID, businessType, configGroup, nil
+}
@@ -0,0 +1,214 @@
+package data
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/helper"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceEdgenextScdnOriginGroupBindHistory returns the SCDN origin group bind history data source
+func DataSourceEdgenextScdnOriginGroupBindHistory() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceScdnOriginGroupBindHistoryRead,
+
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Only return bindings for this domain ID",
+				AtLeastOneOf: []string{"domain_id", "domain", "origin_group_id"},
+			},
+			"domain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return bindings for this domain name",
+			},
+			"origin_group_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only return bindings to this origin group",
+			},
+			"result_output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results to a file",
+			},
+			"history_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Latest matching bind history record ID, 0 if none was found",
+			},
+			"previous_history_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Bind history record ID that preceded the current binding of the domain, 0 if there is none or no domain filter is set. Can be used as `rollback_history_id` of `edgenext_scdn_origin_group_domain_bind`",
+			},
+			"member_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Member ID that performed the binding",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation time",
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Update time",
+			},
+			"bindings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Domain bindings recorded in the history, one item per domain and record, newest first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"history_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Bind history record ID",
+						},
+						"origin_group_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Origin group ID",
+						},
+						"domain_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Domain ID",
+						},
+						"domain_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Domain name",
+						},
+						"current": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether this is the latest binding of the domain",
+						},
+						"member_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Member ID that performed the binding",
+						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Creation time",
+						},
+						"updated_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Update time",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceScdnOriginGroupBindHistoryRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	domainID := d.Get("domain_id").(int)
+	domainFilter := d.Get("domain").(string)
+	originGroupID := d.Get("origin_group_id").(int)
+
+	// The API only returns the latest record of each origin group, so the
+	// history of a domain is assembled from the records of all origin groups
+	log.Printf("[INFO] Querying SCDN origin group bind history: domain_id=%d, domain=%s, origin_group_id=%d",
+		domainID, domainFilter, originGroupID)
+	histories, err := service.ListOriginGroupBindHistories(nil)
+	if err != nil {
+		return fmt.Errorf("failed to query origin group bind history: %w", err)
+	}
+	histories = scdn.FilterOriginGroupBindHistories(histories, domainID, domainFilter)
+
+	d.SetId(fmt.Sprintf("origin-group-bind-history-%d-%s-%d", domainID, domainFilter, originGroupID))
+
+	var latest scdn.OriginGroupBindHistory
+	previousHistoryID := 0
+	if (domainID != 0 || domainFilter != "") && len(histories) > 1 {
+		previousHistoryID = histories[1].ID
+	}
+
+	// Flatten the history records into one binding per domain and record
+	seen := make(map[int]bool)
+	bindingsList := make([]map[string]interface{}, 0, len(histories))
+	for _, history := range histories {
+		for _, domain := range history.Domains {
+			if domainID != 0 && domain.DomainID != domainID {
+				continue
+			}
+			if domainFilter != "" && !strings.EqualFold(domain.DomainName, domainFilter) {
+				continue
+			}
+			current := !seen[domain.DomainID]
+			seen[domain.DomainID] = true
+			if originGroupID != 0 && history.OriginGroupID != originGroupID {
+				continue
+			}
+			bindingsList = append(bindingsList, map[string]interface{}{
+				"history_id":      history.ID,
+				"origin_group_id": history.OriginGroupID,
+				"domain_id":       domain.DomainID,
+				"domain_name":     domain.DomainName,
+				"current":         current,
+				"member_id":       history.MemberID,
+				"created_at":      history.CreatedAt,
+				"updated_at":      history.UpdatedAt,
+			})
+		}
+		if latest.ID == 0 && (originGroupID == 0 || history.OriginGroupID == originGroupID) {
+			latest = history
+		}
+	}
+
+	if err := d.Set("history_id", latest.ID); err != nil {
+		return fmt.Errorf("error setting history_id: %w", err)
+	}
+	if err := d.Set("previous_history_id", previousHistoryID); err != nil {
+		return fmt.Errorf("error setting previous_history_id: %w", err)
+	}
+	if err := d.Set("member_id", latest.MemberID); err != nil {
+		log.Printf("[WARN] Failed to set member_id: %v", err)
+	}
+	if err := d.Set("created_at", latest.CreatedAt); err != nil {
+		log.Printf("[WARN] Failed to set created_at: %v", err)
+	}
+	if err := d.Set("updated_at", latest.UpdatedAt); err != nil {
+		log.Printf("[WARN] Failed to set updated_at: %v", err)
+	}
+	if err := d.Set("bindings", bindingsList); err != nil {
+		return fmt.Errorf("error setting bindings: %w", err)
+	}
+
+	// Write result to output file if specified
+	if outputFile := d.Get("result_output_file").(string); outputFile != "" {
+		outputData := map[string]interface{}{
+			"history_id":          latest.ID,
+			"previous_history_id": previousHistoryID,
+			"member_id":           latest.MemberID,
+			"created_at":          latest.CreatedAt,
+			"updated_at":          latest.UpdatedAt,
+			"bindings":            bindingsList,
+		}
+		if err := helper.WriteToFile(d, outputData); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+	}
+
+	log.Printf("[INFO] SCDN origin group bind history queried successfully: history_id=%d, previous_history_id=%d, bindings=%d",
+		latest.ID, previousHistoryID, len(bindingsList))
+	return nil
+}
@@ -18,9 +18,9 @@ func Resources() map[string]*schema.Resource {
 // DataSources returns all origin group-related data sources
 func DataSources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"edgenext_scdn_origin_group":      data.DataSourceEdgenextScdnOriginGroup(),
-		"edgenext_scdn_origin_groups":     data.DataSourceEdgenextScdnOriginGroups(),
-		"edgenext_scdn_origin_groups_all": data.DataSourceEdgenextScdnOriginGroupsAll(),
-		// "edgenext_scdn_origin_group_bind_history": data.DataSourceEdgenextScdnOriginGroupBindHistory(), // Temporarily disabled due to API ambiguity
+		"edgenext_scdn_origin_group":              data.DataSourceEdgenextScdnOriginGroup(),
+		"edgenext_scdn_origin_groups":             data.DataSourceEdgenextScdnOriginGroups(),
+		"edgenext_scdn_origin_groups_all":         data.DataSourceEdgenextScdnOriginGroupsAll(),
+		"edgenext_scdn_origin_group_bind_history": data.DataSourceEdgenextScdnOriginGroupBindHistory(),
 	}
 }
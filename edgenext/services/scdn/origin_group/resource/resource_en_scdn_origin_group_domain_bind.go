@@ -22,10 +22,12 @@ func ResourceEdgenextScdnOriginGroupDomainBind() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"origin_group_id": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Origin group ID",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "Origin group ID. Computed from the bind history record when `rollback_history_id` is set",
+				ExactlyOneOf: []string{"origin_group_id", "rollback_history_id"},
 			},
 			"domain_ids": {
 				Type:        schema.TypeList,
@@ -54,6 +56,30 @@ func ResourceEdgenextScdnOriginGroupDomainBind() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"rollback_history_id": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "Bind history record ID to roll back to. The domains are bound back to the origin group recorded in that history. It is resolved once when the resource is created and later changes are ignored, since every rollback records a new bind history",
+				ConflictsWith: []string{"domain_ids", "domain_group_ids", "domains"},
+				// The rollback itself changes the previous_history_id of the bind history data source,
+				// so following it would roll back again on every apply
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return d.Id() != "" && old != "0" && old != ""
+				},
+			},
+			"rollback_domain_ids": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				Description:   "Domain IDs to roll back, they must be recorded in the bind history. Defaults to every domain of the record",
+				RequiredWith:  []string{"rollback_history_id"},
+				ConflictsWith: []string{"domain_ids", "domain_group_ids", "domains"},
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
 			"job_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -67,8 +93,11 @@ func resourceScdnOriginGroupDomainBindCreate(d *schema.ResourceData, m interface
 	client := m.(*connectivity.EdgeNextClient)
 	service := scdn.NewScdnService(client)
 
-	originGroupID := d.Get("origin_group_id").(int)
+	if historyID, ok := d.GetOk("rollback_history_id"); ok {
+		return resourceScdnOriginGroupDomainBindRollback(d, m, service, historyID.(int))
+	}
 
+	originGroupID := d.Get("origin_group_id").(int)
 	req := scdn.OriginGroupBindDomainsRequest{
 		OriginGroupID: originGroupID,
 	}
@@ -114,6 +143,38 @@ func resourceScdnOriginGroupDomainBindCreate(d *schema.ResourceData, m interface
 	return resourceScdnOriginGroupDomainBindRead(d, m)
 }
 
+func resourceScdnOriginGroupDomainBindRollback(d *schema.ResourceData, m interface{}, service *scdn.ScdnService, historyID int) error {
+	req := scdn.OriginGroupBindRollbackRequest{
+		HistoryID: historyID,
+	}
+	if domainIDs, ok := d.GetOk("rollback_domain_ids"); ok {
+		for _, v := range domainIDs.([]interface{}) {
+			req.DomainIDs = append(req.DomainIDs, v.(int))
+		}
+	}
+
+	log.Printf("[INFO] Rolling back SCDN origin group binding: history_id=%d", historyID)
+	response, err := service.RollbackOriginGroupBinding(req)
+	if err != nil {
+		return err
+	}
+
+	originGroupID := response.Data.History.OriginGroupID
+	d.SetId(fmt.Sprintf("origin-group-domain-bind-%d", originGroupID))
+
+	if err := d.Set("origin_group_id", originGroupID); err != nil {
+		log.Printf("[WARN] Failed to set origin_group_id: %v", err)
+	}
+	if err := d.Set("job_id", response.Data.JobID); err != nil {
+		log.Printf("[WARN] Failed to set job_id: %v", err)
+	}
+	if err := d.Set("rollback_domain_ids", response.Data.DomainIDs); err != nil {
+		log.Printf("[WARN] Failed to set rollback_domain_ids: %v", err)
+	}
+
+	return resourceScdnOriginGroupDomainBindRead(d, m)
+}
+
 func resourceScdnOriginGroupDomainBindRead(d *schema.ResourceData, m interface{}) error {
 	// Binding is a one-time operation, we can't really "read" the binding state from API
 	// Just verify the resource exists by ensuring origin_group_id is set
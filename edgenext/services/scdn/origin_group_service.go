@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
 )
@@ -304,3 +307,151 @@ func (s *ScdnService) GetOriginGroupBindHistory(req OriginGroupBindHistoryReques
 
 	return response, nil
 }
+
+// originGroupBindHistoryConcurrency is the number of bind history requests in flight at once
+const originGroupBindHistoryConcurrency = 5
+
+// ListOriginGroupBindHistories returns the latest bind history record of each
+// given origin group, or of every origin group when originGroupIDs is empty.
+// The API only keeps the latest record per origin group, so together these
+// records form the binding history of each domain across origin groups
+func (s *ScdnService) ListOriginGroupBindHistories(originGroupIDs []int) ([]OriginGroupBindHistory, error) {
+	if len(originGroupIDs) == 0 {
+		const pageSize = 100
+		for page := 1; ; page++ {
+			listResp, err := s.ListOriginGroups(OriginGroupListRequest{Page: page, PageSize: pageSize})
+			if err != nil {
+				return nil, err
+			}
+			for _, group := range listResp.Data.List {
+				originGroupIDs = append(originGroupIDs, group.ID)
+			}
+			if len(listResp.Data.List) < pageSize || len(originGroupIDs) >= listResp.Data.Total {
+				break
+			}
+		}
+	}
+
+	// The API has no batch endpoint, so the records are fetched concurrently
+	records := make([]OriginGroupBindHistory, len(originGroupIDs))
+	errs := make([]error, len(originGroupIDs))
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, originGroupBindHistoryConcurrency)
+	for i, originGroupID := range originGroupIDs {
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func(i, originGroupID int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			historyResp, err := s.GetOriginGroupBindHistory(OriginGroupBindHistoryRequest{
+				OriginGroupID: originGroupID,
+			})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			records[i] = historyResp.Data.History
+			if records[i].OriginGroupID == 0 {
+				records[i].OriginGroupID = originGroupID
+			}
+		}(i, originGroupID)
+	}
+	wg.Wait()
+
+	histories := make([]OriginGroupBindHistory, 0, len(originGroupIDs))
+	for i, history := range records {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if history.ID != 0 {
+			histories = append(histories, history)
+		}
+	}
+
+	return histories, nil
+}
+
+// FilterOriginGroupBindHistories returns the bind history records that contain
+// the given domain, newest first. A zero domainID or empty domainName matches
+// any domain, so the first record returned for a domain is its current binding
+func FilterOriginGroupBindHistories(histories []OriginGroupBindHistory, domainID int, domainName string) []OriginGroupBindHistory {
+	filtered := make([]OriginGroupBindHistory, 0, len(histories))
+	for _, history := range histories {
+		for _, domain := range history.Domains {
+			if domainID != 0 && domain.DomainID != domainID {
+				continue
+			}
+			if domainName != "" && !strings.EqualFold(domain.DomainName, domainName) {
+				continue
+			}
+			filtered = append(filtered, history)
+			break
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		if filtered[i].CreatedAt != filtered[j].CreatedAt {
+			return filtered[i].CreatedAt > filtered[j].CreatedAt
+		}
+		return filtered[i].ID > filtered[j].ID
+	})
+	return filtered
+}
+
+// RollbackOriginGroupBinding binds domains back to the origin group recorded in
+// a bind history record. Without DomainIDs every domain of the record is
+// rolled back
+func (s *ScdnService) RollbackOriginGroupBinding(req OriginGroupBindRollbackRequest) (*OriginGroupBindRollbackResponse, error) {
+	histories, err := s.ListOriginGroupBindHistories(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rollback origin group binding: %w", err)
+	}
+
+	var history *OriginGroupBindHistory
+	for i := range histories {
+		if histories[i].ID == req.HistoryID {
+			history = &histories[i]
+			break
+		}
+	}
+	if history == nil {
+		return nil, fmt.Errorf("failed to rollback origin group binding: bind history %d not found", req.HistoryID)
+	}
+
+	bindReq := OriginGroupBindDomainsRequest{
+		OriginGroupID: history.OriginGroupID,
+	}
+	if len(req.DomainIDs) == 0 {
+		for _, domain := range history.Domains {
+			bindReq.DomainIDs = append(bindReq.DomainIDs, domain.DomainID)
+		}
+	} else {
+		recorded := make(map[int]bool, len(history.Domains))
+		for _, domain := range history.Domains {
+			recorded[domain.DomainID] = true
+		}
+		for _, domainID := range req.DomainIDs {
+			if !recorded[domainID] {
+				return nil, fmt.Errorf("failed to rollback origin group binding: domain %d is not recorded in bind history %d", domainID, history.ID)
+			}
+		}
+		bindReq.DomainIDs = req.DomainIDs
+	}
+	if len(bindReq.DomainIDs) == 0 {
+		return nil, fmt.Errorf("failed to rollback origin group binding: bind history %d has no domains", history.ID)
+	}
+
+	bindResp, err := s.BindOriginGroupToDomains(bindReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rollback origin group binding: %w", err)
+	}
+
+	return &OriginGroupBindRollbackResponse{
+		Status: bindResp.Status,
+		Data: OriginGroupBindRollbackData{
+			JobID:     bindResp.Data.JobID,
+			DomainIDs: bindReq.DomainIDs,
+			History:   *history,
+		},
+	}, nil
+}
@@ -1,6 +1,7 @@
 package scdn
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -400,3 +401,92 @@ func TestScdnService_GetOriginGroupBindHistory(t *testing.T) {
 		})
 	}
 }
+
+func TestScdnService_RollbackOriginGroupBinding(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     OriginGroupBindRollbackRequest
+		wantErr bool
+	}{
+		{
+			name: "Test RollbackOriginGroupBinding with unknown history",
+			req: OriginGroupBindRollbackRequest{
+				HistoryID: -1,
+			},
+			wantErr: true,
+		},
+	}
+	if !isIntegrationTest() {
+		t.Skip("Skipping integration test: set EDGENEXT_ACCESS_KEY and EDGENEXT_SECRET_KEY to run")
+	}
+
+	client := createTestClient(t)
+	service := NewScdnService(client)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.RollbackOriginGroupBinding(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ScdnService.RollbackOriginGroupBinding() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				t.Logf("Rollback rejected as expected: %s", err.Error())
+				return
+			}
+			t.Logf("Response Status: Code=%d, Message=%s", got.Status.Code, got.Status.Message)
+			t.Logf("JobID=%s, DomainCount=%d", got.Data.JobID, len(got.Data.DomainIDs))
+		})
+	}
+}
+
+func TestFilterOriginGroupBindHistories(t *testing.T) {
+	histories := []OriginGroupBindHistory{
+		{
+			ID:            10,
+			OriginGroupID: 1,
+			Domains:       []OriginGroupBindHistoryDomain{{DomainID: 100, DomainName: "www.example.com"}},
+			CreatedAt:     "2024-01-01 10:00:00",
+		},
+		{
+			ID:            30,
+			OriginGroupID: 3,
+			Domains:       []OriginGroupBindHistoryDomain{{DomainID: 200, DomainName: "api.example.com"}},
+			CreatedAt:     "2024-03-01 10:00:00",
+		},
+		{
+			ID:            20,
+			OriginGroupID: 2,
+			Domains: []OriginGroupBindHistoryDomain{
+				{DomainID: 100, DomainName: "www.example.com"},
+				{DomainID: 200, DomainName: "api.example.com"},
+			},
+			CreatedAt: "2024-02-01 10:00:00",
+		},
+	}
+
+	tests := []struct {
+		name       string
+		domainID   int
+		domainName string
+		want       []int
+	}{
+		{name: "by domain ID", domainID: 100, want: []int{20, 10}},
+		{name: "by domain name", domainName: "API.example.com", want: []int{30, 20}},
+		{name: "by domain ID and name", domainID: 200, domainName: "www.example.com", want: []int{}},
+		{name: "unknown domain", domainID: 300, want: []int{}},
+		{name: "any domain", want: []int{30, 20, 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FilterOriginGroupBindHistories(histories, tt.domainID, tt.domainName)
+			ids := make([]int, 0, len(got))
+			for _, history := range got {
+				ids = append(ids, history.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("FilterOriginGroupBindHistories() = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
@@ -156,6 +156,25 @@ type OriginGroupBindHistoryDomain struct {
 	DomainName string `json:"domain_name"` // Domain name
 }
 
+// OriginGroupBindRollbackRequest rollback to bind history request
+type OriginGroupBindRollbackRequest struct {
+	HistoryID int   `json:"history_id"`           // Bind history record ID to roll back to
+	DomainIDs []int `json:"domain_ids,omitempty"` // Domains to roll back, all domains of the record if empty
+}
+
+// OriginGroupBindRollbackResponse rollback to bind history response
+type OriginGroupBindRollbackResponse struct {
+	Status Status                      `json:"status"`
+	Data   OriginGroupBindRollbackData `json:"data"`
+}
+
+// OriginGroupBindRollbackData rollback to bind history data
+type OriginGroupBindRollbackData struct {
+	JobID     string                 `json:"job_id"`     // Batch job ID
+	DomainIDs []int                  `json:"domain_ids"` // Rolled back domain IDs
+	History   OriginGroupBindHistory `json:"history"`    // Bind history record rolled back to
+}
+
 // OriginGroupInfo origin group information
 type OriginGroupInfo struct {
 	ID        int                 `json:"id"`         // Origin group ID
@@ -65,6 +65,11 @@ func DataSourceEdgenextScdnSecurityProtectionWafConfig() *schema.Resource {
 							Computed:    true,
 							Description: "Protection mode: off, active, block, ban, keep",
 						},
+						"waf_strategy_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "WAF strategy ID",
+						},
 					},
 				},
 			},
@@ -97,6 +102,102 @@ func DataSourceEdgenextScdnSecurityProtectionWafConfig() *schema.Resource {
 					},
 				},
 			},
+			"replay_attack_protection": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Replay attack protection configuration",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status: on, off",
+						},
+						"action": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Action: captcha, deny, watch",
+						},
+						"path": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Protected path list",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"ignore_path": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Ignored path list",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"validity_period": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Validity period in seconds",
+						},
+					},
+				},
+			},
+			"csrf_protection": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "CSRF protection configuration",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status: on, off",
+						},
+						"action": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Action: deny, watch",
+						},
+						"path": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Protected path list",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"ignore_path": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Ignored path list",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"web_shell_protection": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Web shell protection configuration",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status: on, off",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -134,11 +235,12 @@ func dataSourceScdnSecurityProtectionWafConfigRead(d *schema.ResourceData, m int
 	if response.Data.WafRuleConfig != nil {
 		wafRule := []map[string]interface{}{
 			{
-				"id":        response.Data.WafRuleConfig.ID,
-				"status":    response.Data.WafRuleConfig.Status,
-				"ai_status": response.Data.WafRuleConfig.AIStatus,
-				"waf_level": response.Data.WafRuleConfig.WafLevel,
-				"waf_mode":  response.Data.WafRuleConfig.WafMode,
+				"id":              response.Data.WafRuleConfig.ID,
+				"status":          response.Data.WafRuleConfig.Status,
+				"ai_status":       response.Data.WafRuleConfig.AIStatus,
+				"waf_level":       response.Data.WafRuleConfig.WafLevel,
+				"waf_mode":        response.Data.WafRuleConfig.WafMode,
+				"waf_strategy_id": response.Data.WafRuleConfig.WafStrategyID,
 			},
 		}
 		if err := d.Set("waf_rule_config", wafRule); err != nil {
@@ -161,11 +263,60 @@ func dataSourceScdnSecurityProtectionWafConfigRead(d *schema.ResourceData, m int
 		}
 	}
 
+	// Set replay_attack_protection
+	if response.Data.ReplayAttackProtection != nil {
+		replay := []map[string]interface{}{
+			{
+				"id":              response.Data.ReplayAttackProtection.ID,
+				"status":          response.Data.ReplayAttackProtection.Status,
+				"action":          response.Data.ReplayAttackProtection.Action,
+				"path":            response.Data.ReplayAttackProtection.Path,
+				"ignore_path":     response.Data.ReplayAttackProtection.IgnorePath,
+				"validity_period": response.Data.ReplayAttackProtection.ValidityPeriod,
+			},
+		}
+		if err := d.Set("replay_attack_protection", replay); err != nil {
+			return fmt.Errorf("error setting replay_attack_protection: %w", err)
+		}
+	}
+
+	// Set csrf_protection
+	if response.Data.CsrfProtection != nil {
+		csrf := []map[string]interface{}{
+			{
+				"id":          response.Data.CsrfProtection.ID,
+				"status":      response.Data.CsrfProtection.Status,
+				"action":      response.Data.CsrfProtection.Action,
+				"path":        response.Data.CsrfProtection.Path,
+				"ignore_path": response.Data.CsrfProtection.IgnorePath,
+			},
+		}
+		if err := d.Set("csrf_protection", csrf); err != nil {
+			return fmt.Errorf("error setting csrf_protection: %w", err)
+		}
+	}
+
+	// Set web_shell_protection
+	if response.Data.WebShellProtection != nil {
+		webShell := []map[string]interface{}{
+			{
+				"id":     response.Data.WebShellProtection.ID,
+				"status": response.Data.WebShellProtection.Status,
+			},
+		}
+		if err := d.Set("web_shell_protection", webShell); err != nil {
+			return fmt.Errorf("error setting web_shell_protection: %w", err)
+		}
+	}
+
 	// Write result to output file if specified
 	if outputFile := d.Get("result_output_file").(string); outputFile != "" {
 		outputData := map[string]interface{}{
-			"waf_rule_config":    response.Data.WafRuleConfig,
-			"waf_intercept_page": response.Data.WafInterceptPage,
+			"waf_rule_config":          response.Data.WafRuleConfig,
+			"waf_intercept_page":       response.Data.WafInterceptPage,
+			"replay_attack_protection": response.Data.ReplayAttackProtection,
+			"csrf_protection":          response.Data.CsrfProtection,
+			"web_shell_protection":     response.Data.WebShellProtection,
 		}
 		if err := helper.WriteToFile(d, outputData); err != nil {
 			return fmt.Errorf("failed to write output file: %w", err)
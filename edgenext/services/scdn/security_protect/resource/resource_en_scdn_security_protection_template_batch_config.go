@@ -11,6 +11,7 @@ import (
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // ResourceEdgenextScdnSecurityProtectionTemplateBatchConfig returns the SCDN security protection template batch config resource
@@ -192,6 +193,114 @@ func ResourceEdgenextScdnSecurityProtectionTemplateBatchConfig() *schema.Resourc
 								},
 							},
 						},
+						"replay_attack_protection": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "Replay attack protection config",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"status": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Status: on, off, keep",
+									},
+									"action": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Action: captcha, deny, watch, keep",
+									},
+									"path": {
+										Type:        schema.TypeList,
+										Optional:    true,
+										Description: "Protected path list",
+										Elem:        &schema.Schema{Type: schema.TypeString},
+									},
+									"path_action": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringInSlice([]string{"add", "cover"}, false),
+										Description:  "How path is applied to each template: add (append to existing paths) or cover (replace existing paths)",
+									},
+									"ignore_path": {
+										Type:        schema.TypeList,
+										Optional:    true,
+										Description: "Ignored path list",
+										Elem:        &schema.Schema{Type: schema.TypeString},
+									},
+									"ignore_path_action": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringInSlice([]string{"add", "cover"}, false),
+										Description:  "How ignore_path is applied to each template: add (append to existing paths) or cover (replace existing paths)",
+									},
+									"validity_period": {
+										Type:        schema.TypeInt,
+										Optional:    true,
+										Description: "Validity period in seconds",
+									},
+								},
+							},
+						},
+						"csrf_protection": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "CSRF protection config",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"status": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Status: on, off, keep",
+									},
+									"action": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Action: deny, watch, keep",
+									},
+									"path": {
+										Type:        schema.TypeList,
+										Optional:    true,
+										Description: "Protected path list",
+										Elem:        &schema.Schema{Type: schema.TypeString},
+									},
+									"path_action": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringInSlice([]string{"add", "cover"}, false),
+										Description:  "How path is applied to each template: add (append to existing paths) or cover (replace existing paths)",
+									},
+									"ignore_path": {
+										Type:        schema.TypeList,
+										Optional:    true,
+										Description: "Ignored path list",
+										Elem:        &schema.Schema{Type: schema.TypeString},
+									},
+									"ignore_path_action": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringInSlice([]string{"add", "cover"}, false),
+										Description:  "How ignore_path is applied to each template: add (append to existing paths) or cover (replace existing paths)",
+									},
+								},
+							},
+						},
+						"web_shell_protection": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "Web shell protection config",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"status": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Status: on, off",
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -506,6 +615,38 @@ func resourceScdnSecurityProtectionTemplateBatchConfigUpdate(d *schema.ResourceD
 				wafRuleConfig.WafInterceptPage = wafInterceptPageCfg
 			}
 
+			if replay, ok := wafConfigMap["replay_attack_protection"].([]interface{}); ok && len(replay) > 0 && replay[0] != nil {
+				replayMap := replay[0].(map[string]interface{})
+				wafRuleConfig.ReplayAttackProtection = &scdn.BatchUpdateReplayAttackProtectionConfig{
+					Status:           replayMap["status"].(string),
+					Action:           replayMap["action"].(string),
+					Path:             expandScdnWafPathList(replayMap["path"]),
+					PathAction:       replayMap["path_action"].(string),
+					IgnorePath:       expandScdnWafPathList(replayMap["ignore_path"]),
+					IgnorePathAction: replayMap["ignore_path_action"].(string),
+					ValidityPeriod:   replayMap["validity_period"].(int),
+				}
+			}
+
+			if csrf, ok := wafConfigMap["csrf_protection"].([]interface{}); ok && len(csrf) > 0 && csrf[0] != nil {
+				csrfMap := csrf[0].(map[string]interface{})
+				wafRuleConfig.CsrfProtection = &scdn.BatchUpdateCSRFProtectionConfig{
+					Status:           csrfMap["status"].(string),
+					Action:           csrfMap["action"].(string),
+					Path:             expandScdnWafPathList(csrfMap["path"]),
+					PathAction:       csrfMap["path_action"].(string),
+					IgnorePath:       expandScdnWafPathList(csrfMap["ignore_path"]),
+					IgnorePathAction: csrfMap["ignore_path_action"].(string),
+				}
+			}
+
+			if webShell, ok := wafConfigMap["web_shell_protection"].([]interface{}); ok && len(webShell) > 0 && webShell[0] != nil {
+				webShellMap := webShell[0].(map[string]interface{})
+				wafRuleConfig.WebShellProtection = &scdn.WebShellProtectionConfig{
+					Status: webShellMap["status"].(string),
+				}
+			}
+
 			req.WafRuleConfig = wafRuleConfig
 		}
 	}
@@ -542,9 +683,6 @@ func resourceScdnSecurityProtectionTemplateBatchConfigUpdate(d *schema.ResourceD
 				for i, policy := range policies {
 					policyMap := policy.(map[string]interface{})
 					policyCfg := scdn.PreciseAccessControlPolicy{}
-					if val, ok := policyMap["rule_type"].(string); ok {
-						policyCfg.RuleType = val
-					}
 					if val, ok := policyMap["action"].(string); ok {
 						policyCfg.Action = val
 					}
@@ -588,6 +726,12 @@ func resourceScdnSecurityProtectionTemplateBatchConfigUpdate(d *schema.ResourceD
 					if val, ok := policyMap["status"].(int); ok {
 						policyCfg.Status = val
 					}
+					// rule_type is a legacy alias of type, used when type is not set
+					if val, ok := policyMap["type"].(string); ok && val != "" {
+						policyCfg.Type = val
+					} else if val, ok := policyMap["rule_type"].(string); ok {
+						policyCfg.Type = val
+					}
 					preciseAccessControlConfig.Policies[i] = policyCfg
 				}
 			}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -55,6 +56,12 @@ func ResourceEdgenextScdnSecurityProtectionWafConfig() *schema.Resource {
 							Optional:    true,
 							Description: "Protection mode: off, active, block, ban, keep",
 						},
+						"waf_strategy_id": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+							Description: "WAF strategy ID",
+						},
 					},
 				},
 			},
@@ -83,6 +90,95 @@ func ResourceEdgenextScdnSecurityProtectionWafConfig() *schema.Resource {
 					},
 				},
 			},
+			"replay_attack_protection": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Replay attack protection configuration. Removing the block turns the module off",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"status": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Status: on, off, keep",
+						},
+						"action": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Action: captcha, deny, watch, keep",
+						},
+						"path": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Computed:    true,
+							Description: "Protected path list. If not set, keeps the existing list",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"ignore_path": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Computed:    true,
+							Description: "Ignored path list. If not set, keeps the existing list",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"validity_period": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Computed:    true,
+							Description: "Validity period in seconds",
+						},
+					},
+				},
+			},
+			"csrf_protection": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "CSRF protection configuration. Removing the block turns the module off",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"status": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Status: on, off, keep",
+						},
+						"action": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Action: deny, watch, keep",
+						},
+						"path": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Computed:    true,
+							Description: "Protected path list. If not set, keeps the existing list",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"ignore_path": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Computed:    true,
+							Description: "Ignored path list. If not set, keeps the existing list",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"web_shell_protection": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Web shell protection configuration. Removing the block turns the module off",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"status": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Status: on, off",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -92,9 +188,22 @@ func resourceScdnSecurityProtectionWafConfigCreate(d *schema.ResourceData, m int
 	service := scdn.NewScdnService(client)
 
 	businessID := d.Get("business_id").(int)
+	req := expandScdnWafConfigRequest(d)
+
+	log.Printf("[INFO] Creating SCDN security protection WAF config: business_id=%d", businessID)
+	_, err := service.UpdateWafRuleConfig(req)
+	if err != nil {
+		return fmt.Errorf("failed to create WAF rule config: %w", err)
+	}
+
+	d.SetId(fmt.Sprintf("waf-config-%d", businessID))
+	return resourceScdnSecurityProtectionWafConfigRead(d, m)
+}
 
+// expandScdnWafConfigRequest builds the update request from the configured blocks
+func expandScdnWafConfigRequest(d *schema.ResourceData) scdn.WafRuleConfigUpdateRequest {
 	req := scdn.WafRuleConfigUpdateRequest{
-		BusinessID: businessID,
+		BusinessID: d.Get("business_id").(int),
 	}
 
 	// Build waf_rule_config
@@ -115,6 +224,10 @@ func resourceScdnSecurityProtectionWafConfigCreate(d *schema.ResourceData, m int
 			if val, ok := wafRuleMap["waf_mode"].(string); ok && val != "" {
 				wafRule.WafMode = val
 			}
+			// waf_strategy_id is computed, so a value only read back from the API is not sent
+			if val, ok := wafRuleMap["waf_strategy_id"].(int); ok && scdnWafStrategyIDConfigured(d) {
+				wafRule.WafStrategyID = val
+			}
 			req.WafRuleConfig = wafRule
 		}
 	}
@@ -138,14 +251,47 @@ func resourceScdnSecurityProtectionWafConfigCreate(d *schema.ResourceData, m int
 		}
 	}
 
-	log.Printf("[INFO] Creating/Updating SCDN security protection WAF config: business_id=%d", businessID)
-	_, err := service.UpdateWafRuleConfig(req)
-	if err != nil {
-		return fmt.Errorf("failed to create/update WAF rule config: %w", err)
+	// Build replay_attack_protection
+	if v, ok := d.GetOk("replay_attack_protection"); ok {
+		replayList := v.([]interface{})
+		if len(replayList) > 0 && replayList[0] != nil {
+			replayMap := replayList[0].(map[string]interface{})
+			req.ReplayAttackProtection = &scdn.ReplayAttackProtectionConfig{
+				Status:         replayMap["status"].(string),
+				Action:         replayMap["action"].(string),
+				Path:           expandScdnWafPathList(replayMap["path"]),
+				IgnorePath:     expandScdnWafPathList(replayMap["ignore_path"]),
+				ValidityPeriod: replayMap["validity_period"].(int),
+			}
+		}
 	}
 
-	d.SetId(fmt.Sprintf("waf-config-%d", businessID))
-	return resourceScdnSecurityProtectionWafConfigRead(d, m)
+	// Build csrf_protection
+	if v, ok := d.GetOk("csrf_protection"); ok {
+		csrfList := v.([]interface{})
+		if len(csrfList) > 0 && csrfList[0] != nil {
+			csrfMap := csrfList[0].(map[string]interface{})
+			req.CsrfProtection = &scdn.CSRFProtectionConfig{
+				Status:     csrfMap["status"].(string),
+				Action:     csrfMap["action"].(string),
+				Path:       expandScdnWafPathList(csrfMap["path"]),
+				IgnorePath: expandScdnWafPathList(csrfMap["ignore_path"]),
+			}
+		}
+	}
+
+	// Build web_shell_protection
+	if v, ok := d.GetOk("web_shell_protection"); ok {
+		webShellList := v.([]interface{})
+		if len(webShellList) > 0 && webShellList[0] != nil {
+			webShellMap := webShellList[0].(map[string]interface{})
+			req.WebShellProtection = &scdn.WebShellProtectionConfig{
+				Status: webShellMap["status"].(string),
+			}
+		}
+	}
+
+	return req
 }
 
 func resourceScdnSecurityProtectionWafConfigRead(d *schema.ResourceData, m interface{}) error {
@@ -168,10 +314,11 @@ func resourceScdnSecurityProtectionWafConfigRead(d *schema.ResourceData, m inter
 	if response.Data.WafRuleConfig != nil {
 		wafRule := []map[string]interface{}{
 			{
-				"status":    response.Data.WafRuleConfig.Status,
-				"ai_status": response.Data.WafRuleConfig.AIStatus,
-				"waf_level": response.Data.WafRuleConfig.WafLevel,
-				"waf_mode":  response.Data.WafRuleConfig.WafMode,
+				"status":          response.Data.WafRuleConfig.Status,
+				"ai_status":       response.Data.WafRuleConfig.AIStatus,
+				"waf_level":       response.Data.WafRuleConfig.WafLevel,
+				"waf_mode":        response.Data.WafRuleConfig.WafMode,
+				"waf_strategy_id": response.Data.WafRuleConfig.WafStrategyID,
 			},
 		}
 		if err := d.Set("waf_rule_config", wafRule); err != nil {
@@ -193,11 +340,78 @@ func resourceScdnSecurityProtectionWafConfigRead(d *schema.ResourceData, m inter
 		}
 	}
 
+	// The protection modules are only read back when they are managed by this
+	// resource, so that Delete does not reset modules it never configured
+
+	// Set replay_attack_protection
+	if _, ok := d.GetOk("replay_attack_protection"); ok && response.Data.ReplayAttackProtection != nil {
+		replay := []map[string]interface{}{
+			{
+				"status":          response.Data.ReplayAttackProtection.Status,
+				"action":          response.Data.ReplayAttackProtection.Action,
+				"path":            response.Data.ReplayAttackProtection.Path,
+				"ignore_path":     response.Data.ReplayAttackProtection.IgnorePath,
+				"validity_period": response.Data.ReplayAttackProtection.ValidityPeriod,
+			},
+		}
+		if err := d.Set("replay_attack_protection", replay); err != nil {
+			return fmt.Errorf("error setting replay_attack_protection: %w", err)
+		}
+	}
+
+	// Set csrf_protection
+	if _, ok := d.GetOk("csrf_protection"); ok && response.Data.CsrfProtection != nil {
+		csrf := []map[string]interface{}{
+			{
+				"status":      response.Data.CsrfProtection.Status,
+				"action":      response.Data.CsrfProtection.Action,
+				"path":        response.Data.CsrfProtection.Path,
+				"ignore_path": response.Data.CsrfProtection.IgnorePath,
+			},
+		}
+		if err := d.Set("csrf_protection", csrf); err != nil {
+			return fmt.Errorf("error setting csrf_protection: %w", err)
+		}
+	}
+
+	// Set web_shell_protection
+	if _, ok := d.GetOk("web_shell_protection"); ok && response.Data.WebShellProtection != nil {
+		webShell := []map[string]interface{}{
+			{
+				"status": response.Data.WebShellProtection.Status,
+			},
+		}
+		if err := d.Set("web_shell_protection", webShell); err != nil {
+			return fmt.Errorf("error setting web_shell_protection: %w", err)
+		}
+	}
+
 	return nil
 }
 
 func resourceScdnSecurityProtectionWafConfigUpdate(d *schema.ResourceData, m interface{}) error {
-	return resourceScdnSecurityProtectionWafConfigCreate(d, m)
+	client := m.(*connectivity.EdgeNextClient)
+	service := scdn.NewScdnService(client)
+
+	businessID := d.Get("business_id").(int)
+	req := expandScdnWafConfigRequest(d)
+
+	// Turn off protection modules whose block was removed from the configuration
+	var removed []string
+	for _, key := range scdnWafProtectionModules {
+		if _, ok := d.GetOk(key); !ok && d.HasChange(key) {
+			removed = append(removed, key)
+		}
+	}
+	resetScdnWafProtectionModules(&req, removed)
+
+	log.Printf("[INFO] Updating SCDN security protection WAF config: business_id=%d", businessID)
+	_, err := service.UpdateWafRuleConfig(req)
+	if err != nil {
+		return fmt.Errorf("failed to update WAF rule config: %w", err)
+	}
+
+	return resourceScdnSecurityProtectionWafConfigRead(d, m)
 }
 
 func resourceScdnSecurityProtectionWafConfigDelete(d *schema.ResourceData, m interface{}) error {
@@ -223,6 +437,15 @@ func resourceScdnSecurityProtectionWafConfigDelete(d *schema.ResourceData, m int
 		},
 	}
 
+	// Only reset the protection modules managed by this resource
+	var managed []string
+	for _, key := range scdnWafProtectionModules {
+		if _, ok := d.GetOk(key); ok {
+			managed = append(managed, key)
+		}
+	}
+	resetScdnWafProtectionModules(&req, managed)
+
 	log.Printf("[INFO] Resetting SCDN security protection WAF config: business_id=%d", businessID)
 	_, err := service.UpdateWafRuleConfig(req)
 	if err != nil {
@@ -232,3 +455,48 @@ func resourceScdnSecurityProtectionWafConfigDelete(d *schema.ResourceData, m int
 	d.SetId("")
 	return nil
 }
+
+// scdnWafProtectionModules lists the optional protection module blocks
+var scdnWafProtectionModules = []string{"replay_attack_protection", "csrf_protection", "web_shell_protection"}
+
+// resetScdnWafProtectionModules adds requests turning the given modules off
+func resetScdnWafProtectionModules(req *scdn.WafRuleConfigUpdateRequest, keys []string) {
+	for _, key := range keys {
+		switch key {
+		case "replay_attack_protection":
+			req.ReplayAttackProtection = &scdn.ReplayAttackProtectionConfig{Status: "off", Action: "keep"}
+		case "csrf_protection":
+			req.CsrfProtection = &scdn.CSRFProtectionConfig{Status: "off", Action: "keep"}
+		case "web_shell_protection":
+			req.WebShellProtection = &scdn.WebShellProtectionConfig{Status: "off"}
+		}
+	}
+}
+
+// scdnWafStrategyIDConfigured reports whether waf_strategy_id is set in the configuration
+func scdnWafStrategyIDConfigured(d *schema.ResourceData) bool {
+	raw := d.GetRawConfig()
+	if raw.IsNull() {
+		return false
+	}
+	blocks := raw.GetAttr("waf_rule_config")
+	if blocks.IsNull() || !blocks.IsKnown() || blocks.LengthInt() == 0 {
+		return false
+	}
+	block := blocks.Index(cty.NumberIntVal(0))
+	return !block.IsNull() && !block.GetAttr("waf_strategy_id").IsNull()
+}
+
+// expandScdnWafPathList converts a path list from the schema into a string
+// slice, nil when the list is empty so that the field is omitted
+func expandScdnWafPathList(v interface{}) []string {
+	var paths []string
+	if list, ok := v.([]interface{}); ok {
+		for _, item := range list {
+			if path, ok := item.(string); ok && path != "" {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths
+}
@@ -85,26 +85,26 @@ type WafRuleConfigGetData struct {
 // ReplayAttackProtectionConfig replay attack protection config
 type ReplayAttackProtectionConfig struct {
 	ID             int      `json:"id,omitempty"`
-	Status         string   `json:"status"`                    // on, off, keep
-	Action         string   `json:"action"`                    // captcha, deny, watch, keep
-	Path           []string `json:"path"`                      // Path list
-	IgnorePath     []string `json:"ignore_path"`               // Ignore path list
+	Status         string   `json:"status,omitempty"`          // on, off, keep
+	Action         string   `json:"action,omitempty"`          // captcha, deny, watch, keep
+	Path           []string `json:"path,omitempty"`            // Path list
+	IgnorePath     []string `json:"ignore_path,omitempty"`     // Ignore path list
 	ValidityPeriod int      `json:"validity_period,omitempty"` // Validity period
 }
 
 // CSRFProtectionConfig CSRF protection config
 type CSRFProtectionConfig struct {
 	ID         int      `json:"id,omitempty"`
-	Status     string   `json:"status"`      // on, off, keep
-	Action     string   `json:"action"`      // deny, watch, keep
-	Path       []string `json:"path"`        // Path list
-	IgnorePath []string `json:"ignore_path"` // Ignore path list
+	Status     string   `json:"status,omitempty"`      // on, off, keep
+	Action     string   `json:"action,omitempty"`      // deny, watch, keep
+	Path       []string `json:"path,omitempty"`        // Path list
+	IgnorePath []string `json:"ignore_path,omitempty"` // Ignore path list
 }
 
 // WebShellProtectionConfig web shell protection config
 type WebShellProtectionConfig struct {
 	ID     int    `json:"id,omitempty"`
-	Status string `json:"status"` // on, off
+	Status string `json:"status,omitempty"` // on, off
 }
 
 // BatchUpdateReplayAttackProtectionConfig batch update replay attack protection config
@@ -112,9 +112,9 @@ type BatchUpdateReplayAttackProtectionConfig struct {
 	ID               int      `json:"id,omitempty"`
 	Status           string   `json:"status"`                       // on, off, keep
 	Action           string   `json:"action"`                       // captcha, deny, watch, keep
-	Path             []string `json:"path"`                         // Path list
+	Path             []string `json:"path,omitempty"`               // Path list
 	PathAction       string   `json:"path_action,omitempty"`        // add, cover
-	IgnorePath       []string `json:"ignore_path"`                  // Ignore path list
+	IgnorePath       []string `json:"ignore_path,omitempty"`        // Ignore path list
 	IgnorePathAction string   `json:"ignore_path_action,omitempty"` // add, cover
 	ValidityPeriod   int      `json:"validity_period,omitempty"`    // Validity period
 }
@@ -125,8 +125,8 @@ type BatchUpdateCSRFProtectionConfig struct {
 	Status           string   `json:"status"`                       // on, off, keep
 	Action           string   `json:"action"`                       // deny, watch, keep
 	PathAction       string   `json:"path_action,omitempty"`        // add, cover
-	Path             []string `json:"path"`                         // Path list
-	IgnorePath       []string `json:"ignore_path"`                  // Ignore path list
+	Path             []string `json:"path,omitempty"`               // Path list
+	IgnorePath       []string `json:"ignore_path,omitempty"`        // Ignore path list
 	IgnorePathAction string   `json:"ignore_path_action,omitempty"` // add, cover
 }
 
@@ -142,18 +142,18 @@ type BatchUpdateWafRuleConfigRequest struct {
 // WafRuleConfig WAF rule config
 type WafRuleConfig struct {
 	ID            int    `json:"id,omitempty"`
-	Status        string `json:"status"`                    // on, off, keep
-	AIStatus      string `json:"ai_status"`                 // on, off
-	WafLevel      string `json:"waf_level"`                 // general, strict, keep
-	WafMode       string `json:"waf_mode"`                  // off, active, block, ban, keep
+	Status        string `json:"status,omitempty"`          // on, off, keep
+	AIStatus      string `json:"ai_status,omitempty"`       // on, off
+	WafLevel      string `json:"waf_level,omitempty"`       // general, strict, keep
+	WafMode       string `json:"waf_mode,omitempty"`        // off, active, block, ban, keep
 	WafStrategyID int    `json:"waf_strategy_id,omitempty"` // WAF strategy ID
 }
 
 // WafInterceptPage WAF intercept page config
 type WafInterceptPage struct {
 	ID      int    `json:"id,omitempty"`
-	Status  string `json:"status"`            // on, off
-	Type    string `json:"type"`              // custom, default, keep
+	Status  string `json:"status,omitempty"`  // on, off
+	Type    string `json:"type,omitempty"`    // custom, default, keep
 	Content string `json:"content,omitempty"` // Custom content
 }
 
@@ -441,7 +441,6 @@ type UpdatePreciseAccessControlConfigRequest struct {
 
 // PreciseAccessControlPolicy precise access control policy
 type PreciseAccessControlPolicy struct {
-	RuleType   string                   `json:"type,omitempty"`        // Policy type (maps to "type" in API)
 	Action     string                   `json:"action,omitempty"`      // Policy action
 	ActionData map[string]interface{}   `json:"action_data,omitempty"` // Action data
 	Rules      []map[string]interface{} `json:"rules,omitempty"`       // Rules list (flexible key-value pairs)
@@ -0,0 +1,865 @@
+package scdn
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/helper"
+)
+
+// ============================================================================
+// Wirefilter Expressions
+// ============================================================================
+// Cache rules select requests with a "wirefilter" expression such as
+// (http.request.postfix in {"css" "js"}). The helpers below parse and check the
+// syntax of those expressions locally, and compile a structured WirefilterMatch
+// to and from the expression text.
+
+// wirefilterDocumentedFields are the fields known to be accepted by SCDN
+// rules. Expressions are only checked for syntax, so other fields are allowed
+// but reported as warnings by WirefilterFieldWarnings
+var wirefilterDocumentedFields = []string{
+	"http.request.postfix",
+	"http.request.uri.path",
+}
+
+// wirefilterOperators maps accepted operator spellings to their canonical form
+var wirefilterOperators = map[string]string{
+	"eq":       "eq",
+	"==":       "eq",
+	"ne":       "ne",
+	"!=":       "ne",
+	"contains": "contains",
+	"matches":  "matches",
+	"~":        "matches",
+	"in":       "in",
+	"lt":       "lt",
+	"<":        "lt",
+	"le":       "le",
+	"<=":       "le",
+	"gt":       "gt",
+	">":        "gt",
+	"ge":       "ge",
+	">=":       "ge",
+}
+
+// WirefilterNode is a node of a parsed wirefilter expression
+type WirefilterNode interface {
+	wirefilterNode()
+}
+
+// WirefilterLogical combines terms with "and" or "or"
+type WirefilterLogical struct {
+	Op    string           // "and" or "or"
+	Terms []WirefilterNode // At least two terms
+}
+
+// WirefilterNot negates a term
+type WirefilterNot struct {
+	Term WirefilterNode
+}
+
+// WirefilterComparison compares a field with one value or a set of values
+type WirefilterComparison struct {
+	Field  string   // Field name, e.g. http.request.uri.path
+	Key    string   // Map key for map fields, e.g. the header name
+	Op     string   // Canonical operator
+	Values []string // Unquoted values; more than one only for "in"
+	Quoted bool     // Values are quoted strings rather than numbers, IPs or CIDRs
+}
+
+func (*WirefilterLogical) wirefilterNode()    {}
+func (*WirefilterNot) wirefilterNode()        {}
+func (*WirefilterComparison) wirefilterNode() {}
+
+// ParseWirefilter parses and validates a wirefilter expression. An empty
+// expression means "match all" and yields a nil node.
+func ParseWirefilter(expr string) (WirefilterNode, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+
+	tokens, err := lexWirefilter(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &wirefilterParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != wirefilterTokenEOF {
+		return nil, p.errorf(tok, "unexpected %q", tok.text)
+	}
+	return node, nil
+}
+
+// ValidateWirefilter reports whether expr is a valid wirefilter expression
+func ValidateWirefilter(expr string) error {
+	_, err := ParseWirefilter(expr)
+	return err
+}
+
+// FormatWirefilter renders a node in canonical form, wrapped in parentheses
+func FormatWirefilter(node WirefilterNode) string {
+	if node == nil {
+		return ""
+	}
+	return "(" + formatWirefilterNode(node) + ")"
+}
+
+// NormalizeWirefilter parses expr and renders it in canonical form
+func NormalizeWirefilter(expr string) (string, error) {
+	node, err := ParseWirefilter(expr)
+	if err != nil {
+		return "", err
+	}
+	return FormatWirefilter(node), nil
+}
+
+// WirefilterEquivalent reports whether two expressions have the same canonical
+// form. Invalid expressions are only equivalent when textually identical.
+func WirefilterEquivalent(a, b string) bool {
+	if a == b {
+		return true
+	}
+	na, errA := NormalizeWirefilter(a)
+	nb, errB := NormalizeWirefilter(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return na == nb
+}
+
+func formatWirefilterNode(node WirefilterNode) string {
+	switch n := node.(type) {
+	case *WirefilterLogical:
+		parts := make([]string, 0, len(n.Terms))
+		for _, term := range n.Terms {
+			if _, ok := term.(*WirefilterLogical); ok {
+				parts = append(parts, "("+formatWirefilterNode(term)+")")
+			} else {
+				parts = append(parts, formatWirefilterNode(term))
+			}
+		}
+		return strings.Join(parts, " "+n.Op+" ")
+	case *WirefilterNot:
+		return "not (" + formatWirefilterNode(n.Term) + ")"
+	case *WirefilterComparison:
+		var b strings.Builder
+		b.WriteString(n.Field)
+		if n.Key != "" {
+			b.WriteString("[" + strconv.Quote(n.Key) + "]")
+		}
+		b.WriteString(" " + n.Op + " ")
+		values := make([]string, 0, len(n.Values))
+		for _, v := range n.Values {
+			if n.Quoted {
+				values = append(values, strconv.Quote(v))
+			} else {
+				values = append(values, v)
+			}
+		}
+		if n.Op == "in" {
+			b.WriteString("{" + strings.Join(values, " ") + "}")
+		} else if len(values) > 0 {
+			b.WriteString(values[0])
+		}
+		return b.String()
+	}
+	return ""
+}
+
+// ============================================================================
+// Lexer
+// ============================================================================
+
+type wirefilterTokenKind int
+
+const (
+	wirefilterTokenEOF    wirefilterTokenKind = iota
+	wirefilterTokenIdent                      // Field names and keyword operators
+	wirefilterTokenString                     // Quoted string literal (unescaped)
+	wirefilterTokenBare                       // Bare literal: number, IP or CIDR
+	wirefilterTokenPunct                      // ( ) { } [ ] , and symbolic operators
+)
+
+type wirefilterToken struct {
+	kind wirefilterTokenKind
+	text string
+	pos  int
+}
+
+func lexWirefilter(expr string) ([]wirefilterToken, error) {
+	var tokens []wirefilterToken
+	i := 0
+	for i < len(expr) {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"':
+			start := i
+			i++
+			var b strings.Builder
+			closed := false
+			for i < len(expr) {
+				if expr[i] == '\\' && i+1 < len(expr) {
+					// Only quotes and backslashes are escapes, anything else
+					// (e.g. \d in a regular expression) is kept verbatim
+					if expr[i+1] != '"' && expr[i+1] != '\\' {
+						b.WriteByte('\\')
+					}
+					b.WriteByte(expr[i+1])
+					i += 2
+					continue
+				}
+				if expr[i] == '"' {
+					closed = true
+					i++
+					break
+				}
+				b.WriteByte(expr[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("invalid wirefilter expression at position %d: unterminated string", start+1)
+			}
+			tokens = append(tokens, wirefilterToken{kind: wirefilterTokenString, text: b.String(), pos: start})
+		case isWirefilterIdentStart(c):
+			start := i
+			for i < len(expr) && isWirefilterIdentChar(expr[i]) {
+				i++
+			}
+			// An identifier followed by ':' is the start of an IPv6 literal such as fe80::1
+			if i < len(expr) && expr[i] == ':' {
+				for i < len(expr) && isWirefilterBareChar(expr[i]) {
+					i++
+				}
+				tokens = append(tokens, wirefilterToken{kind: wirefilterTokenBare, text: expr[start:i], pos: start})
+				continue
+			}
+			tokens = append(tokens, wirefilterToken{kind: wirefilterTokenIdent, text: expr[start:i], pos: start})
+		case c >= '0' && c <= '9' || c == ':':
+			start := i
+			for i < len(expr) && isWirefilterBareChar(expr[i]) {
+				i++
+			}
+			tokens = append(tokens, wirefilterToken{kind: wirefilterTokenBare, text: expr[start:i], pos: start})
+		default:
+			start := i
+			two := ""
+			if i+1 < len(expr) {
+				two = expr[i : i+2]
+			}
+			switch {
+			case two == "==" || two == "!=" || two == "&&" || two == "||" || two == "<=" || two == ">=":
+				tokens = append(tokens, wirefilterToken{kind: wirefilterTokenPunct, text: two, pos: start})
+				i += 2
+			case strings.ContainsRune("(){}[],~!<>", rune(c)):
+				tokens = append(tokens, wirefilterToken{kind: wirefilterTokenPunct, text: string(c), pos: start})
+				i++
+			default:
+				return nil, fmt.Errorf("invalid wirefilter expression at position %d: unexpected character %q", start+1, c)
+			}
+		}
+	}
+	tokens = append(tokens, wirefilterToken{kind: wirefilterTokenEOF, pos: len(expr)})
+	return tokens, nil
+}
+
+func isWirefilterIdentStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+func isWirefilterIdentChar(c byte) bool {
+	return isWirefilterIdentStart(c) || c >= '0' && c <= '9' || c == '.'
+}
+
+func isWirefilterBareChar(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F' || c == ':' || c == '.' || c == '/' || c == '-'
+}
+
+// ============================================================================
+// Parser
+// ============================================================================
+
+type wirefilterParser struct {
+	tokens []wirefilterToken
+	pos    int
+}
+
+func (p *wirefilterParser) peek() wirefilterToken {
+	return p.tokens[p.pos]
+}
+
+func (p *wirefilterParser) next() wirefilterToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != wirefilterTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *wirefilterParser) errorf(tok wirefilterToken, format string, args ...interface{}) error {
+	if tok.kind == wirefilterTokenEOF {
+		return fmt.Errorf("invalid wirefilter expression at end of input: %s", fmt.Sprintf(format, args...))
+	}
+	return fmt.Errorf("invalid wirefilter expression at position %d: %s", tok.pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *wirefilterParser) accept(texts ...string) bool {
+	tok := p.peek()
+	if tok.kind != wirefilterTokenIdent && tok.kind != wirefilterTokenPunct {
+		return false
+	}
+	for _, text := range texts {
+		if tok.text == text {
+			p.next()
+			return true
+		}
+	}
+	return false
+}
+
+func (p *wirefilterParser) expect(text string) error {
+	if !p.accept(text) {
+		tok := p.peek()
+		if tok.kind == wirefilterTokenEOF {
+			return p.errorf(tok, "expected %q", text)
+		}
+		return p.errorf(tok, "expected %q, found %q", text, tok.text)
+	}
+	return nil
+}
+
+func (p *wirefilterParser) parseOr() (WirefilterNode, error) {
+	return p.parseLogical("or", []string{"or", "||"}, p.parseAnd)
+}
+
+func (p *wirefilterParser) parseAnd() (WirefilterNode, error) {
+	return p.parseLogical("and", []string{"and", "&&"}, p.parseNot)
+}
+
+func (p *wirefilterParser) parseLogical(op string, spellings []string, operand func() (WirefilterNode, error)) (WirefilterNode, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	terms := appendWirefilterTerm(nil, op, first)
+	for p.accept(spellings...) {
+		term, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		terms = appendWirefilterTerm(terms, op, term)
+	}
+
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return &WirefilterLogical{Op: op, Terms: terms}, nil
+}
+
+// appendWirefilterTerm flattens nested logical nodes of the same operator
+func appendWirefilterTerm(terms []WirefilterNode, op string, term WirefilterNode) []WirefilterNode {
+	if logical, ok := term.(*WirefilterLogical); ok && logical.Op == op {
+		return append(terms, logical.Terms...)
+	}
+	return append(terms, term)
+}
+
+func (p *wirefilterParser) parseNot() (WirefilterNode, error) {
+	if p.accept("not", "!") {
+		term, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &WirefilterNot{Term: term}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *wirefilterParser) parsePrimary() (WirefilterNode, error) {
+	if p.accept("(") {
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return node, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *wirefilterParser) parseComparison() (WirefilterNode, error) {
+	fieldTok := p.next()
+	if fieldTok.kind != wirefilterTokenIdent {
+		if fieldTok.kind == wirefilterTokenEOF {
+			return nil, p.errorf(fieldTok, "expected a field name")
+		}
+		return nil, p.errorf(fieldTok, "expected a field name, found %q", fieldTok.text)
+	}
+
+	cmp := &WirefilterComparison{Field: fieldTok.text}
+
+	// Map fields such as headers are indexed with a quoted key
+	if p.accept("[") {
+		keyTok := p.next()
+		if keyTok.kind != wirefilterTokenString {
+			return nil, p.errorf(keyTok, "field %s must be indexed with a quoted key", cmp.Field)
+		}
+		cmp.Key = keyTok.text
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+	}
+
+	opTok := p.next()
+	op, ok := wirefilterOperators[opTok.text]
+	if !ok || opTok.kind == wirefilterTokenString || opTok.kind == wirefilterTokenBare {
+		return nil, p.errorf(opTok, "expected a comparison operator after %s", cmp.Field)
+	}
+	cmp.Op = op
+
+	if op == "in" {
+		if err := p.expect("{"); err != nil {
+			return nil, err
+		}
+		for !p.accept("}") {
+			p.accept(",")
+			if p.peek().kind == wirefilterTokenPunct && p.peek().text == "}" {
+				continue
+			}
+			value, err := p.parseValue(cmp)
+			if err != nil {
+				return nil, err
+			}
+			cmp.Values = append(cmp.Values, value)
+		}
+		if len(cmp.Values) == 0 {
+			return nil, p.errorf(opTok, "set for %s must not be empty", cmp.Field)
+		}
+		return cmp, nil
+	}
+
+	value, err := p.parseValue(cmp)
+	if err != nil {
+		return nil, err
+	}
+	cmp.Values = []string{value}
+	return cmp, nil
+}
+
+// parseValue parses a quoted string or a bare number, IP address or CIDR. The
+// value type is taken from the literal, not from the field
+func (p *wirefilterParser) parseValue(cmp *WirefilterComparison) (string, error) {
+	tok := p.next()
+	if tok.kind != wirefilterTokenString && tok.kind != wirefilterTokenBare {
+		if tok.kind == wirefilterTokenEOF {
+			return "", p.errorf(tok, "expected a value for %s", cmp.Field)
+		}
+		return "", p.errorf(tok, "expected a value for %s, found %q (strings must be quoted)", cmp.Field, tok.text)
+	}
+
+	quoted := tok.kind == wirefilterTokenString
+	if len(cmp.Values) > 0 && quoted != cmp.Quoted {
+		return "", p.errorf(tok, "set for %s mixes quoted and unquoted values", cmp.Field)
+	}
+	cmp.Quoted = quoted
+
+	if quoted {
+		if cmp.Op == "matches" {
+			if _, err := regexp.Compile(tok.text); err != nil {
+				return "", p.errorf(tok, "invalid regular expression %q: %v", tok.text, err)
+			}
+		}
+		return tok.text, nil
+	}
+
+	if cmp.Op == "matches" || cmp.Op == "contains" {
+		return "", p.errorf(tok, "operator %q expects a quoted string value", cmp.Op)
+	}
+	if _, err := strconv.Atoi(tok.text); err != nil && net.ParseIP(tok.text) == nil {
+		if _, _, err := net.ParseCIDR(tok.text); err != nil {
+			return "", p.errorf(tok, "invalid value %q, expected a number, IP address or CIDR", tok.text)
+		}
+	}
+	return tok.text, nil
+}
+
+// WirefilterFieldWarnings returns a warning for each field of a valid
+// expression that is not documented for SCDN rules. The API may still accept
+// such fields, so they are not treated as errors
+func WirefilterFieldWarnings(expr string) []string {
+	node, err := ParseWirefilter(expr)
+	if err != nil || node == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var warnings []string
+	var walk func(WirefilterNode)
+	walk = func(node WirefilterNode) {
+		switch n := node.(type) {
+		case *WirefilterLogical:
+			for _, term := range n.Terms {
+				walk(term)
+			}
+		case *WirefilterNot:
+			walk(n.Term)
+		case *WirefilterComparison:
+			if seen[n.Field] {
+				return
+			}
+			seen[n.Field] = true
+			if msg := WirefilterFieldWarning(n.Field); msg != "" {
+				warnings = append(warnings, msg)
+			}
+		}
+	}
+	walk(node)
+	return warnings
+}
+
+// WirefilterFieldWarning returns the warning for a field that is not
+// documented for SCDN rules, or an empty string for a documented field
+func WirefilterFieldWarning(field string) string {
+	if containsString(wirefilterDocumentedFields, field) {
+		return ""
+	}
+	msg := fmt.Sprintf("field %q is not a documented SCDN rule field, the expression was only checked for syntax", field)
+	if suggestion := helper.SuggestClosest(field, wirefilterDocumentedFields); suggestion != "" {
+		msg += fmt.Sprintf(", did you mean %q?", suggestion)
+	}
+	return msg
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ============================================================================
+// Structured Match Builder
+// ============================================================================
+
+// WirefilterMatch is a structured form of a wirefilter expression. The All,
+// Any and Not groups are combined with "and".
+type WirefilterMatch struct {
+	All *WirefilterConditions // Every condition must match
+	Any *WirefilterConditions // At least one condition must match
+	Not *WirefilterConditions // No condition may match
+}
+
+// WirefilterConditions is a group of request conditions. Each list field is a
+// single condition that matches any of its values.
+type WirefilterConditions struct {
+	URIPrefix []string             // http.request.uri.path starts with any prefix
+	URIPath   []string             // http.request.uri.path equals any path
+	Extension []string             // http.request.postfix is any extension
+	Method    []string             // http.request.method is any method
+	Host      []string             // http.host is any host
+	Header    []WirefilterKeyMatch // http.request.headers["name"] comparisons
+	QueryArg  []WirefilterKeyMatch // http.request.uri.args["name"] comparisons
+}
+
+// WirefilterKeyMatch compares one header or query argument
+type WirefilterKeyMatch struct {
+	Name     string // Header or query argument name
+	Operator string // "eq", "ne", "contains" or "matches"
+	Value    string // Value to compare with
+}
+
+// WirefilterKeyOperators lists the operators supported for header and query argument conditions
+var WirefilterKeyOperators = []string{"eq", "ne", "contains", "matches"}
+
+// Compile renders the match as a wirefilter expression
+func (m *WirefilterMatch) Compile() (string, error) {
+	var terms []WirefilterNode
+
+	if m.All != nil {
+		nodes, err := m.All.nodes()
+		if err != nil {
+			return "", fmt.Errorf("all: %w", err)
+		}
+		terms = append(terms, nodes...)
+	}
+	if m.Any != nil {
+		nodes, err := m.Any.nodes()
+		if err != nil {
+			return "", fmt.Errorf("any: %w", err)
+		}
+		if len(nodes) == 1 {
+			terms = append(terms, nodes[0])
+		} else if len(nodes) > 1 {
+			terms = append(terms, &WirefilterLogical{Op: "or", Terms: nodes})
+		}
+	}
+	if m.Not != nil {
+		nodes, err := m.Not.nodes()
+		if err != nil {
+			return "", fmt.Errorf("not: %w", err)
+		}
+		if len(nodes) == 1 {
+			terms = append(terms, &WirefilterNot{Term: nodes[0]})
+		} else if len(nodes) > 1 {
+			terms = append(terms, &WirefilterNot{Term: &WirefilterLogical{Op: "or", Terms: nodes}})
+		}
+	}
+
+	switch len(terms) {
+	case 0:
+		return "", fmt.Errorf("match must contain at least one condition")
+	case 1:
+		return FormatWirefilter(terms[0]), nil
+	default:
+		return FormatWirefilter(&WirefilterLogical{Op: "and", Terms: terms}), nil
+	}
+}
+
+func (c *WirefilterConditions) nodes() ([]WirefilterNode, error) {
+	var nodes []WirefilterNode
+
+	if len(c.URIPrefix) > 0 {
+		quoted := make([]string, 0, len(c.URIPrefix))
+		for _, prefix := range c.URIPrefix {
+			if !strings.HasPrefix(prefix, "/") {
+				return nil, fmt.Errorf("uri_prefix %q must start with \"/\"", prefix)
+			}
+			quoted = append(quoted, regexp.QuoteMeta(prefix))
+		}
+		pattern := "^" + quoted[0]
+		if len(quoted) > 1 {
+			pattern = "^(?:" + strings.Join(quoted, "|") + ")"
+		}
+		nodes = append(nodes, &WirefilterComparison{Field: "http.request.uri.path", Op: "matches", Values: []string{pattern}, Quoted: true})
+	}
+	if len(c.URIPath) > 0 {
+		for _, path := range c.URIPath {
+			if !strings.HasPrefix(path, "/") {
+				return nil, fmt.Errorf("uri_path %q must start with \"/\"", path)
+			}
+		}
+		nodes = append(nodes, wirefilterSetComparison("http.request.uri.path", c.URIPath))
+	}
+	if len(c.Extension) > 0 {
+		extensions := make([]string, 0, len(c.Extension))
+		for _, ext := range c.Extension {
+			ext = strings.TrimPrefix(ext, ".")
+			if ext == "" {
+				return nil, fmt.Errorf("extension must not be empty")
+			}
+			extensions = append(extensions, ext)
+		}
+		nodes = append(nodes, &WirefilterComparison{Field: "http.request.postfix", Op: "in", Values: extensions, Quoted: true})
+	}
+	if len(c.Method) > 0 {
+		methods := make([]string, 0, len(c.Method))
+		for _, method := range c.Method {
+			methods = append(methods, strings.ToUpper(method))
+		}
+		nodes = append(nodes, &WirefilterComparison{Field: "http.request.method", Op: "in", Values: methods, Quoted: true})
+	}
+	if len(c.Host) > 0 {
+		nodes = append(nodes, wirefilterSetComparison("http.host", c.Host))
+	}
+	for _, header := range c.Header {
+		node, err := header.node("http.request.headers", strings.ToLower(header.Name))
+		if err != nil {
+			return nil, fmt.Errorf("header: %w", err)
+		}
+		nodes = append(nodes, node)
+	}
+	for _, arg := range c.QueryArg {
+		node, err := arg.node("http.request.uri.args", arg.Name)
+		if err != nil {
+			return nil, fmt.Errorf("query_arg: %w", err)
+		}
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
+
+func (k WirefilterKeyMatch) node(field, name string) (WirefilterNode, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name must not be empty")
+	}
+	op := k.Operator
+	if op == "" {
+		op = "eq"
+	}
+	if !containsString(WirefilterKeyOperators, op) {
+		return nil, fmt.Errorf("unsupported operator %q for %q, must be one of %s", op, name, strings.Join(WirefilterKeyOperators, ", "))
+	}
+	if op == "matches" {
+		if _, err := regexp.Compile(k.Value); err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q for %q: %v", k.Value, name, err)
+		}
+	}
+	return &WirefilterComparison{Field: field, Key: name, Op: op, Values: []string{k.Value}, Quoted: true}, nil
+}
+
+func wirefilterSetComparison(field string, values []string) *WirefilterComparison {
+	if len(values) == 1 {
+		return &WirefilterComparison{Field: field, Op: "eq", Values: []string{values[0]}, Quoted: true}
+	}
+	return &WirefilterComparison{Field: field, Op: "in", Values: append([]string(nil), values...), Quoted: true}
+}
+
+// DecompileWirefilter converts an expression back into a WirefilterMatch. It
+// returns an error when the expression cannot be represented structurally.
+func DecompileWirefilter(expr string) (*WirefilterMatch, error) {
+	node, err := ParseWirefilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, fmt.Errorf("empty expression has no structured form")
+	}
+
+	terms := []WirefilterNode{node}
+	if logical, ok := node.(*WirefilterLogical); ok && logical.Op == "and" {
+		terms = logical.Terms
+	}
+
+	match := &WirefilterMatch{}
+	var allNodes []WirefilterNode
+	for _, term := range terms {
+		switch t := term.(type) {
+		case *WirefilterComparison:
+			allNodes = append(allNodes, t)
+		case *WirefilterLogical:
+			if match.Any != nil {
+				return nil, fmt.Errorf("expression has more than one \"or\" group")
+			}
+			conditions, err := wirefilterConditionsFromNodes(t.Terms)
+			if err != nil {
+				return nil, err
+			}
+			match.Any = conditions
+		case *WirefilterNot:
+			if match.Not != nil {
+				return nil, fmt.Errorf("expression has more than one \"not\" group")
+			}
+			inner := []WirefilterNode{t.Term}
+			if logical, ok := t.Term.(*WirefilterLogical); ok {
+				if logical.Op != "or" {
+					return nil, fmt.Errorf("negated \"and\" groups have no structured form")
+				}
+				inner = logical.Terms
+			}
+			conditions, err := wirefilterConditionsFromNodes(inner)
+			if err != nil {
+				return nil, err
+			}
+			match.Not = conditions
+		}
+	}
+	if len(allNodes) > 0 {
+		conditions, err := wirefilterConditionsFromNodes(allNodes)
+		if err != nil {
+			return nil, err
+		}
+		match.All = conditions
+	}
+
+	return match, nil
+}
+
+func wirefilterConditionsFromNodes(nodes []WirefilterNode) (*WirefilterConditions, error) {
+	c := &WirefilterConditions{}
+	for _, node := range nodes {
+		cmp, ok := node.(*WirefilterComparison)
+		if !ok {
+			return nil, fmt.Errorf("nested groups have no structured form")
+		}
+		if !cmp.Quoted {
+			return nil, fmt.Errorf("condition on %s with unquoted values has no structured form", cmp.Field)
+		}
+
+		var target *[]string
+		values := cmp.Values
+		switch {
+		case cmp.Field == "http.request.uri.path" && cmp.Op == "matches":
+			prefixes, ok := wirefilterPrefixesFromPattern(cmp.Values[0])
+			if !ok {
+				return nil, fmt.Errorf("pattern %q is not a path prefix", cmp.Values[0])
+			}
+			target, values = &c.URIPrefix, prefixes
+		case cmp.Field == "http.request.uri.path" && (cmp.Op == "eq" || cmp.Op == "in"):
+			target = &c.URIPath
+		case cmp.Field == "http.request.postfix" && (cmp.Op == "eq" || cmp.Op == "in"):
+			target = &c.Extension
+		case cmp.Field == "http.request.method" && (cmp.Op == "eq" || cmp.Op == "in"):
+			target = &c.Method
+		case cmp.Field == "http.host" && (cmp.Op == "eq" || cmp.Op == "in"):
+			target = &c.Host
+		case cmp.Field == "http.request.headers" && cmp.Op != "in":
+			c.Header = append(c.Header, WirefilterKeyMatch{Name: cmp.Key, Operator: cmp.Op, Value: cmp.Values[0]})
+			continue
+		case cmp.Field == "http.request.uri.args" && cmp.Op != "in":
+			c.QueryArg = append(c.QueryArg, WirefilterKeyMatch{Name: cmp.Key, Operator: cmp.Op, Value: cmp.Values[0]})
+			continue
+		default:
+			return nil, fmt.Errorf("condition on %s with operator %q has no structured form", cmp.Field, cmp.Op)
+		}
+
+		if len(*target) > 0 {
+			return nil, fmt.Errorf("field %s is compared more than once in a group", cmp.Field)
+		}
+		*target = append([]string(nil), values...)
+	}
+	return c, nil
+}
+
+// wirefilterPrefixesFromPattern recognises the ^prefix and ^(?:a|b) patterns
+// generated for uri_prefix
+func wirefilterPrefixesFromPattern(pattern string) ([]string, bool) {
+	if !strings.HasPrefix(pattern, "^") {
+		return nil, false
+	}
+	body := pattern[1:]
+	grouped := strings.HasPrefix(body, "(?:") && strings.HasSuffix(body, ")")
+	if grouped {
+		body = body[3 : len(body)-1]
+	}
+
+	var prefixes []string
+	var current strings.Builder
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case c == '\\' && i+1 < len(body):
+			current.WriteByte(body[i+1])
+			i++
+		case c == '|' && grouped:
+			prefixes = append(prefixes, current.String())
+			current.Reset()
+		case strings.IndexByte(`.+*?()[]{}^$|`, c) >= 0:
+			return nil, false
+		default:
+			current.WriteByte(c)
+		}
+	}
+	prefixes = append(prefixes, current.String())
+
+	for _, prefix := range prefixes {
+		if !strings.HasPrefix(prefix, "/") {
+			return nil, false
+		}
+	}
+	return prefixes, true
+}
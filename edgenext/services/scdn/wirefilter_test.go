@@ -0,0 +1,334 @@
+package scdn
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// Wirefilter Tests
+// ============================================================================
+
+func TestParseWirefilter_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want string
+	}{
+		{
+			name: "empty expression matches all",
+			expr: "",
+			want: "",
+		},
+		{
+			name: "path equality",
+			expr: `(http.request.uri.path eq "/test")`,
+			want: `(http.request.uri.path eq "/test")`,
+		},
+		{
+			name: "extension set",
+			expr: `(http.request.postfix in {"css" "js" "txt"})`,
+			want: `(http.request.postfix in {"css" "js" "txt"})`,
+		},
+		{
+			name: "symbolic operators are canonicalised",
+			expr: `http.request.method == "GET" && !(http.host != "example.com")`,
+			want: `(http.request.method eq "GET" and not (http.host ne "example.com"))`,
+		},
+		{
+			name: "nested groups of the same operator are flattened",
+			expr: `(http.host eq "a" and (http.request.method eq "GET" and http.request.postfix eq "js"))`,
+			want: `(http.host eq "a" and http.request.method eq "GET" and http.request.postfix eq "js")`,
+		},
+		{
+			name: "header, ip and port fields",
+			expr: `http.request.headers["x-debug"] contains "1" or ip.src in {10.0.0.0/8, 192.168.1.1} or http.request.port ge 8080`,
+			want: `(http.request.headers["x-debug"] contains "1" or ip.src in {10.0.0.0/8 192.168.1.1} or http.request.port ge 8080)`,
+		},
+		{
+			name: "regular expression escapes are kept",
+			expr: `http.request.uri.path matches "^/v\d+/"`,
+			want: `(http.request.uri.path matches "^/v\\d+/")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeWirefilter(tt.expr)
+			if err != nil {
+				t.Fatalf("NormalizeWirefilter(%q) error = %v", tt.expr, err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeWirefilter(%q) = %q, want %q", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWirefilter_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr string
+	}{
+		{
+			name:    "missing operator",
+			expr:    `http.request.uri.path "/a"`,
+			wantErr: "expected a comparison operator",
+		},
+		{
+			name:    "unbalanced parentheses",
+			expr:    `(http.host eq "a"`,
+			wantErr: `expected ")"`,
+		},
+		{
+			name:    "unterminated string",
+			expr:    `http.host eq "a`,
+			wantErr: "unterminated string",
+		},
+		{
+			name:    "unquoted string value",
+			expr:    `http.host eq example`,
+			wantErr: "strings must be quoted",
+		},
+		{
+			name:    "invalid regular expression",
+			expr:    `http.request.uri.path matches "^/a("`,
+			wantErr: "invalid regular expression",
+		},
+		{
+			name:    "unquoted regular expression",
+			expr:    `http.request.uri.path matches 1`,
+			wantErr: `operator "matches" expects a quoted string value`,
+		},
+		{
+			name:    "invalid bare value",
+			expr:    `ip.src eq 300.1.1.1`,
+			wantErr: "expected a number, IP address or CIDR",
+		},
+		{
+			name:    "mixed set",
+			expr:    `ip.src in {"a" 10.0.0.1}`,
+			wantErr: "mixes quoted and unquoted values",
+		},
+		{
+			name:    "unquoted map key",
+			expr:    `http.request.headers[a] eq "a"`,
+			wantErr: "must be indexed with a quoted key",
+		},
+		{
+			name:    "trailing tokens",
+			expr:    `http.host eq "a" http.host eq "b"`,
+			wantErr: "unexpected",
+		},
+		{
+			name:    "empty set",
+			expr:    `http.request.postfix in {}`,
+			wantErr: "must not be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWirefilter(tt.expr)
+			if err == nil {
+				t.Fatalf("ValidateWirefilter(%q) expected error", tt.expr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateWirefilter(%q) error = %q, want it to contain %q", tt.expr, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWirefilterFieldWarnings(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want []string
+	}{
+		{name: "documented fields", expr: `http.request.uri.path eq "/a" and http.request.postfix in {"js"}`},
+		{name: "invalid expression", expr: `http.host eq`},
+		{
+			name: "undocumented fields are reported once",
+			expr: `http.host eq "a" or http.request.uri.pth eq "/a" or http.host eq "b"`,
+			want: []string{`"http.host"`, `did you mean "http.request.uri.path"?`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WirefilterFieldWarnings(tt.expr)
+			if len(tt.want) == 0 {
+				if len(got) != 0 {
+					t.Errorf("WirefilterFieldWarnings(%q) = %v, want none", tt.expr, got)
+				}
+				return
+			}
+			if len(got) != 2 {
+				t.Fatalf("WirefilterFieldWarnings(%q) = %v, want 2 warnings", tt.expr, got)
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(got[i], want) {
+					t.Errorf("warning %q does not contain %q", got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestWirefilterFieldWarning(t *testing.T) {
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{field: "http.request.uri.path", want: ""},
+		{field: "http.request.postfix", want: ""},
+		{field: "http.request.method", want: `field "http.request.method" is not a documented SCDN rule field`},
+		{field: "http.request.uri.pth", want: `did you mean "http.request.uri.path"?`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			got := WirefilterFieldWarning(tt.field)
+			if tt.want == "" {
+				if got != "" {
+					t.Errorf("WirefilterFieldWarning(%q) = %q, want none", tt.field, got)
+				}
+				return
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("WirefilterFieldWarning(%q) = %q, want it to contain %q", tt.field, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWirefilterMatch_Compile(t *testing.T) {
+	tests := []struct {
+		name  string
+		match WirefilterMatch
+		want  string
+	}{
+		{
+			name: "single extension set",
+			match: WirefilterMatch{
+				All: &WirefilterConditions{Extension: []string{"css", ".js"}},
+			},
+			want: `(http.request.postfix in {"css" "js"})`,
+		},
+		{
+			name: "all, any and not groups",
+			match: WirefilterMatch{
+				All: &WirefilterConditions{
+					URIPrefix: []string{"/static/"},
+					Method:    []string{"get", "head"},
+				},
+				Any: &WirefilterConditions{
+					Header:   []WirefilterKeyMatch{{Name: "X-Cache", Value: "on"}},
+					QueryArg: []WirefilterKeyMatch{{Name: "v", Operator: "matches", Value: "^[0-9]+$"}},
+				},
+				Not: &WirefilterConditions{
+					URIPath: []string{"/static/index.html"},
+				},
+			},
+			want: `(http.request.uri.path matches "^/static/" and http.request.method in {"GET" "HEAD"} and ` +
+				`(http.request.headers["x-cache"] eq "on" or http.request.uri.args["v"] matches "^[0-9]+$") and ` +
+				`not (http.request.uri.path eq "/static/index.html"))`,
+		},
+		{
+			name: "multiple prefixes share one pattern",
+			match: WirefilterMatch{
+				Any: &WirefilterConditions{URIPrefix: []string{"/a.b/", "/c/"}},
+			},
+			want: `(http.request.uri.path matches "^(?:/a\\.b/|/c/)")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.match.Compile()
+			if err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Compile() = %q, want %q", got, tt.want)
+			}
+			if err := ValidateWirefilter(got); err != nil {
+				t.Errorf("compiled expression does not parse: %v", err)
+			}
+		})
+	}
+}
+
+func TestWirefilterMatch_CompileErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		match WirefilterMatch
+	}{
+		{name: "empty match", match: WirefilterMatch{All: &WirefilterConditions{}}},
+		{name: "relative prefix", match: WirefilterMatch{All: &WirefilterConditions{URIPrefix: []string{"static/"}}}},
+		{name: "bad header operator", match: WirefilterMatch{All: &WirefilterConditions{Header: []WirefilterKeyMatch{{Name: "a", Operator: "lt", Value: "1"}}}}},
+		{name: "empty query arg name", match: WirefilterMatch{All: &WirefilterConditions{QueryArg: []WirefilterKeyMatch{{Value: "1"}}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.match.Compile(); err == nil {
+				t.Errorf("Compile() expected error")
+			}
+		})
+	}
+}
+
+func TestDecompileWirefilter_RoundTrip(t *testing.T) {
+	matches := []WirefilterMatch{
+		{All: &WirefilterConditions{Extension: []string{"css", "js"}}},
+		{
+			All: &WirefilterConditions{URIPrefix: []string{"/static/", "/assets/"}, Host: []string{"a.example.com", "b.example.com"}},
+			Not: &WirefilterConditions{Method: []string{"POST"}, QueryArg: []WirefilterKeyMatch{{Name: "nocache", Operator: "eq", Value: "1"}}},
+		},
+		{Any: &WirefilterConditions{URIPath: []string{"/a"}, Header: []WirefilterKeyMatch{{Name: "x-a", Operator: "contains", Value: "b"}}}},
+	}
+
+	for _, match := range matches {
+		expr, err := match.Compile()
+		if err != nil {
+			t.Fatalf("Compile() error = %v", err)
+		}
+		got, err := DecompileWirefilter(expr)
+		if err != nil {
+			t.Fatalf("DecompileWirefilter(%q) error = %v", expr, err)
+		}
+		if !reflect.DeepEqual(*got, match) {
+			t.Errorf("DecompileWirefilter(%q) = %+v, want %+v", expr, *got, match)
+		}
+	}
+}
+
+func TestDecompileWirefilter_Unrepresentable(t *testing.T) {
+	exprs := []string{
+		"",
+		`http.user_agent contains "bot"`,
+		`http.host eq "a" and http.host eq "b"`,
+		`(http.host eq "a" or http.host eq "b") and (http.request.method eq "GET" or http.request.method eq "HEAD")`,
+		`http.request.uri.path matches "^/a|/b"`,
+		`not (http.host eq "a" and http.request.method eq "GET")`,
+	}
+
+	for _, expr := range exprs {
+		if _, err := DecompileWirefilter(expr); err == nil {
+			t.Errorf("DecompileWirefilter(%q) expected error", expr)
+		}
+	}
+}
+
+func TestWirefilterEquivalent(t *testing.T) {
+	if !WirefilterEquivalent(`http.request.uri.path == "/a"`, `(http.request.uri.path eq "/a")`) {
+		t.Errorf("expected expressions to be equivalent")
+	}
+	if WirefilterEquivalent(`http.request.uri.path eq "/a"`, `http.request.uri.path eq "/b"`) {
+		t.Errorf("expected expressions to differ")
+	}
+}
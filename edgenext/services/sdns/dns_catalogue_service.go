@@ -0,0 +1,147 @@
+package sdns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/helper"
+)
+
+// dnsCatalogue caches the record types and lines of a client, they only change with the service
+type dnsCatalogue struct {
+	mu    sync.Mutex
+	types []DnsRecordTypeInfo
+	lines map[int][]DnsRecordLine
+}
+
+var (
+	dnsCataloguesMu sync.Mutex
+	dnsCatalogues   = make(map[*connectivity.EdgeNextClient]*dnsCatalogue)
+)
+
+func (s *SdnsService) catalogue() *dnsCatalogue {
+	dnsCataloguesMu.Lock()
+	defer dnsCataloguesMu.Unlock()
+
+	c, ok := dnsCatalogues[s.client]
+	if !ok {
+		c = &dnsCatalogue{lines: make(map[int][]DnsRecordLine)}
+		dnsCatalogues[s.client] = c
+	}
+	return c
+}
+
+// ListDnsRecordLines Lists the resolution lines available to a domain, flattened with Parent set.
+// A domainID of 0 lists the lines of the account.
+func (s *SdnsService) ListDnsRecordLines(domainID int) ([]DnsRecordLine, error) {
+	var resp DnsRecordLinesResponse
+	err := s.callAPI(context.Background(), "GET", EndpointDnsRecordLines, DnsRecordLinesRequest{DomainID: domainID}, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return flattenDnsRecordLines(resp.Data, ""), nil
+}
+
+func flattenDnsRecordLines(lines []DnsRecordLine, parent string) []DnsRecordLine {
+	flat := make([]DnsRecordLine, 0, len(lines))
+	for _, line := range lines {
+		children := line.Children
+		line.Children = nil
+		if line.Parent == "" {
+			line.Parent = parent
+		}
+		flat = append(flat, line)
+		flat = append(flat, flattenDnsRecordLines(children, line.Code)...)
+	}
+	return flat
+}
+
+// CachedDnsRecordTypes is ListDnsRecordTypes, called once per client
+func (s *SdnsService) CachedDnsRecordTypes() ([]DnsRecordTypeInfo, error) {
+	c := s.catalogue()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.types == nil {
+		types, err := s.ListDnsRecordTypes()
+		if err != nil {
+			return nil, err
+		}
+		c.types = types
+	}
+	return c.types, nil
+}
+
+// CachedDnsRecordLines is ListDnsRecordLines, called once per client and domain
+func (s *SdnsService) CachedDnsRecordLines(domainID int) ([]DnsRecordLine, error) {
+	c := s.catalogue()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	lines, ok := c.lines[domainID]
+	if !ok {
+		var err error
+		lines, err = s.ListDnsRecordLines(domainID)
+		if err != nil {
+			return nil, err
+		}
+		c.lines[domainID] = lines
+	}
+	return lines, nil
+}
+
+// ValidateDnsRecordTypeAndView checks recordType and view against the cached catalogue of the
+// domain. An empty catalogue is not checked.
+func (s *SdnsService) ValidateDnsRecordTypeAndView(domainID int, recordType, view string) error {
+	types, err := s.CachedDnsRecordTypes()
+	if err != nil {
+		return fmt.Errorf("failed to list DNS record types: %w", err)
+	}
+	if err := checkDnsRecordType(types, recordType); err != nil {
+		return err
+	}
+
+	lines, err := s.CachedDnsRecordLines(domainID)
+	if err != nil {
+		return fmt.Errorf("failed to list DNS record lines: %w", err)
+	}
+	return checkDnsRecordView(lines, view)
+}
+
+func checkDnsRecordType(types []DnsRecordTypeInfo, recordType string) error {
+	if len(types) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		if strings.EqualFold(t.Type, recordType) {
+			return nil
+		}
+		names = append(names, t.Type)
+	}
+	return fmt.Errorf("record type %q is not supported%s", recordType, didYouMean(recordType, names))
+}
+
+func checkDnsRecordView(lines []DnsRecordLine, view string) error {
+	if len(lines) == 0 {
+		return nil
+	}
+	codes := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line.Code == view {
+			return nil
+		}
+		codes = append(codes, line.Code)
+	}
+	return fmt.Errorf("view %q is not a valid line, see the edgenext_sdns_record_lines data source%s", view, didYouMean(view, codes))
+}
+
+func didYouMean(value string, candidates []string) string {
+	if suggestion := helper.SuggestClosest(value, candidates); suggestion != "" {
+		return fmt.Sprintf(", did you mean %q?", suggestion)
+	}
+	return ""
+}
@@ -0,0 +1,61 @@
+package sdns
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFlattenDnsRecordLines(t *testing.T) {
+	lines := []DnsRecordLine{
+		{Code: "any", Name: "Default"},
+		{Code: "cn", Name: "China", Children: []DnsRecordLine{
+			{Code: "cn_telecom", Name: "China Telecom", Children: []DnsRecordLine{
+				{Code: "cn_telecom_bj", Name: "China Telecom Beijing"},
+			}},
+		}},
+	}
+
+	want := []DnsRecordLine{
+		{Code: "any", Name: "Default"},
+		{Code: "cn", Name: "China"},
+		{Code: "cn_telecom", Name: "China Telecom", Parent: "cn"},
+		{Code: "cn_telecom_bj", Name: "China Telecom Beijing", Parent: "cn_telecom"},
+	}
+	if got := flattenDnsRecordLines(lines, ""); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected lines:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestCheckDnsRecordTypeAndView(t *testing.T) {
+	types := []DnsRecordTypeInfo{{Type: "A"}, {Type: "AAAA"}, {Type: "CNAME"}}
+	lines := []DnsRecordLine{{Code: "any"}, {Code: "telecom"}, {Code: "unicom"}}
+
+	if err := checkDnsRecordType(types, "cname"); err != nil {
+		t.Errorf("expected cname to be accepted, got %v", err)
+	}
+	if err := checkDnsRecordType(types, "CNAM"); err == nil || !strings.Contains(err.Error(), `did you mean "CNAME"`) {
+		t.Errorf("expected a suggestion for CNAM, got %v", err)
+	}
+	if err := checkDnsRecordType(nil, "ANY"); err != nil {
+		t.Errorf("expected an empty catalogue to accept every type, got %v", err)
+	}
+	if err := checkDnsRecordView(lines, "telecom"); err != nil {
+		t.Errorf("expected telecom to be accepted, got %v", err)
+	}
+	if err := checkDnsRecordView(lines, "telcom"); err == nil || !strings.Contains(err.Error(), `did you mean "telecom"`) {
+		t.Errorf("expected a suggestion for telcom, got %v", err)
+	}
+}
+
+func TestDnsRecordTypeInfoUnmarshal(t *testing.T) {
+	var types []DnsRecordTypeInfo
+	if err := json.Unmarshal([]byte(`["A", {"type": "MX", "desc": "Mail exchange"}]`), &types); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	want := []DnsRecordTypeInfo{{Type: "A"}, {Type: "MX", Description: "Mail exchange"}}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("unexpected types: %+v", types)
+	}
+}
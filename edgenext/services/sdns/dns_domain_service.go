@@ -33,6 +33,38 @@ func (s *SdnsService) ListDnsDomains(req DnsDomainListRequest) (*DnsDomainListDa
 	return &resp.Data, nil
 }
 
+// ListAllDnsDomains Lists the DNS domains matching req across all pages
+func (s *SdnsService) ListAllDnsDomains(req DnsDomainListRequest) ([]DnsDomainInfo, error) {
+	if req.PerPage == 0 {
+		req.PerPage = 500
+	}
+
+	domains := make([]DnsDomainInfo, 0)
+	for page := 1; ; page++ {
+		req.Page = page
+		resp, err := s.ListDnsDomains(req)
+		if err != nil {
+			return nil, err
+		}
+		domains = append(domains, resp.List...)
+		if len(resp.List) == 0 || len(domains) >= resp.Total {
+			return domains, nil
+		}
+	}
+}
+
+// BatchAddDnsDomains Adds DNS domains in one call, domains that could not be added are reported
+// in the Fail list of the result
+func (s *SdnsService) BatchAddDnsDomains(domains []string) (*DnsDomainBatchAddData, error) {
+	req := DnsDomainBatchAddRequest{Domains: domains}
+	var resp DnsDomainBatchAddResponse
+	err := s.callAPI(context.Background(), "POST", EndpointDnsDomainBatchAdd, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
 // AddDnsDomain Adds a new DNS domain
 func (s *SdnsService) AddDnsDomain(domainName string) (*DnsDomainAddData, error) {
 	req := DnsDomainAddRequest{Domain: domainName}
@@ -49,3 +81,57 @@ func (s *SdnsService) DeleteDnsDomain(domainIDs []int) error {
 	req := DnsDomainDeleteRequest{DomainIDs: domainIDs}
 	return s.callAPI(context.Background(), "DELETE", EndpointDnsDomainBatchDelete, req, nil)
 }
+
+// GetDnsDomainServers Gets the name servers assigned to a DNS domain
+func (s *SdnsService) GetDnsDomainServers(domainID int) (*DnsDomainServers, error) {
+	req := DnsDomainServersRequest{DomainID: domainID}
+	var resp DnsDomainServersResponse
+	err := s.callAPI(context.Background(), "GET", EndpointDnsDomainServers, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// GetDnsDomainStat Gets the record counts of a DNS domain
+func (s *SdnsService) GetDnsDomainStat(domainID int) (*DnsDomainStat, error) {
+	req := DnsDomainStatRequest{DomainID: domainID}
+	var resp DnsDomainStatResponse
+	err := s.callAPI(context.Background(), "GET", EndpointDnsDomainStat, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// IsDnsDomainDelegated reports whether the domain is delegated to the service, that is whether the
+// name servers in the registry are the assigned ones
+func IsDnsDomainDelegated(servers *DnsDomainServers) bool {
+	if servers == nil || len(servers.Servers) == 0 || len(servers.CurrentServers) == 0 {
+		return false
+	}
+
+	return sameDnsHostNames(servers.Servers, servers.CurrentServers)
+}
+
+// sameDnsHostNames reports whether a and b hold the same host names, ignoring case, order and the
+// trailing dot
+func sameDnsHostNames(a, b []string) bool {
+	set := func(names []string) map[string]bool {
+		out := make(map[string]bool, len(names))
+		for _, name := range names {
+			out[normalizeDnsHostName(name)] = true
+		}
+		return out
+	}
+	setA, setB := set(a), set(b)
+	if len(setA) != len(setB) {
+		return false
+	}
+	for name := range setA {
+		if !setB[name] {
+			return false
+		}
+	}
+	return true
+}
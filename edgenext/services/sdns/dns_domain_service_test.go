@@ -1,6 +1,7 @@
 package sdns
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -71,3 +72,41 @@ func TestSdnsService_DnsDomainCRUD(t *testing.T) {
 		}
 	})
 }
+
+func TestIsDnsDomainDelegated(t *testing.T) {
+	assigned := []string{"ns1.example.net", "ns2.example.net"}
+	cases := []struct {
+		name    string
+		servers *DnsDomainServers
+		want    bool
+	}{
+		{"no servers", nil, false},
+		{"no current servers", &DnsDomainServers{Servers: assigned}, false},
+		{"matching servers", &DnsDomainServers{Servers: assigned, CurrentServers: []string{"NS2.example.net.", "ns1.example.net."}}, true},
+		{"partial delegation", &DnsDomainServers{Servers: assigned, CurrentServers: []string{"ns1.example.net", "ns.registrar.com"}}, false},
+		{"extra servers", &DnsDomainServers{Servers: assigned, CurrentServers: append([]string{"ns.registrar.com"}, assigned...)}, false},
+	}
+	for _, c := range cases {
+		if got := IsDnsDomainDelegated(c.servers); got != c.want {
+			t.Errorf("%s: IsDnsDomainDelegated = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestDnsDomainServersUnmarshal(t *testing.T) {
+	var list DnsDomainServers
+	if err := json.Unmarshal([]byte(`["ns1.example.net","ns2.example.net"]`), &list); err != nil {
+		t.Fatalf("unmarshal list: %v", err)
+	}
+	if len(list.Servers) != 2 || list.CurrentServers != nil {
+		t.Errorf("unmarshal list = %+v", list)
+	}
+
+	var object DnsDomainServers
+	if err := json.Unmarshal([]byte(`{"servers":["ns1.example.net"],"current_servers":["ns.registrar.com"]}`), &object); err != nil {
+		t.Fatalf("unmarshal object: %v", err)
+	}
+	if len(object.Servers) != 1 || len(object.CurrentServers) != 1 || object.CurrentServers[0] != "ns.registrar.com" {
+		t.Errorf("unmarshal object = %+v", object)
+	}
+}
@@ -0,0 +1,72 @@
+package sdns
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListDnsRecordGroups Lists the record groups of a domain
+func (s *SdnsService) ListDnsRecordGroups(req DnsRecordGroupListRequest) (*DnsRecordGroupListData, error) {
+	var resp DnsRecordGroupListResponse
+	err := s.callAPI(context.Background(), "GET", EndpointDnsRecordGroupList, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// AddDnsRecordGroup Adds a record group to a domain
+func (s *SdnsService) AddDnsRecordGroup(req DnsRecordGroupAddRequest) (*DnsRecordGroupAddResponse, error) {
+	var resp DnsRecordGroupAddResponse
+	err := s.callAPI(context.Background(), "POST", EndpointDnsRecordGroupAdd, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteDnsRecordGroup Deletes a record group, the records of the group are kept
+func (s *SdnsService) DeleteDnsRecordGroup(domainID, groupID int) error {
+	req := DnsRecordGroupDelRequest{DomainID: domainID, GroupID: groupID}
+	return s.callAPI(context.Background(), "DELETE", EndpointDnsRecordGroupDelete, req, nil)
+}
+
+// ListAllDnsRecordGroups Lists every record group matching req, following all pages
+func (s *SdnsService) ListAllDnsRecordGroups(req DnsRecordGroupListRequest) ([]DnsRecordGroup, error) {
+	if req.PerPage == 0 {
+		req.PerPage = 500
+	}
+
+	groups := make([]DnsRecordGroup, 0)
+	for page := 1; ; page++ {
+		req.Page = page
+		resp, err := s.ListDnsRecordGroups(req)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, resp.List...)
+		// The server may cap per_page, so rely on total rather than the page size
+		if len(resp.List) == 0 || len(groups) >= resp.Total {
+			return groups, nil
+		}
+	}
+}
+
+// GetDnsRecordGroupInfo Gets record group info by listing and filtering
+func (s *SdnsService) GetDnsRecordGroupInfo(domainID, groupID int) (*DnsRecordGroup, error) {
+	groups, err := s.ListAllDnsRecordGroups(DnsRecordGroupListRequest{DomainID: domainID})
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range groups {
+		if g.ID == groupID {
+			return &g, nil
+		}
+	}
+	return nil, fmt.Errorf("record group not found: %d", groupID)
+}
+
+// BindRecordsToGroup Adds records to or removes records from a record group
+func (s *SdnsService) BindRecordsToGroup(req DnsRecordGroupRelationRequest) error {
+	return s.callAPI(context.Background(), "POST", EndpointDnsRecordGroupRelation, req, nil)
+}
@@ -0,0 +1,117 @@
+package sdns
+
+import (
+	"testing"
+)
+
+func TestSdnsService_DnsRecordGroupCRUD(t *testing.T) {
+	if !isIntegrationTest() {
+		t.Skip("Skipping integration test")
+	}
+
+	client := createTestClient(t)
+	service := NewSdnsService(client)
+
+	// We need a domain and a record for testing record groups
+	domainName := "test-dns-record-group-domain.com"
+	service.AddDnsDomain(domainName)
+	defer func() {
+		resp, _ := service.ListDnsDomains(DnsDomainListRequest{Domain: domainName})
+		var ids []int
+		for _, d := range resp.List {
+			if d.Domain == domainName {
+				ids = append(ids, d.ID)
+			}
+		}
+		if len(ids) > 0 {
+			service.DeleteDnsDomain(ids)
+		}
+	}()
+
+	resp, _ := service.ListDnsDomains(DnsDomainListRequest{Domain: domainName})
+	if len(resp.List) == 0 {
+		t.Fatalf("Failed to prepare test domain")
+	}
+	domainID := resp.List[0].ID
+
+	recordID, err := service.AddDnsRecord(DnsRecordAddRequest{
+		DomainID:    domainID,
+		RecordName:  "pay",
+		RecordType:  "A",
+		RecordView:  "any",
+		RecordValue: "1.2.3.4",
+		RecordTTL:   600,
+	})
+	if err != nil {
+		t.Fatalf("AddDnsRecord failed: %v", err)
+	}
+
+	groupName := "test-record-group"
+	var groupID int
+
+	// 1. Add
+	t.Run("AddGroup", func(t *testing.T) {
+		resp, err := service.AddDnsRecordGroup(DnsRecordGroupAddRequest{
+			DomainID:  domainID,
+			GroupName: groupName,
+			Remark:    "Test record group",
+		})
+		if err != nil {
+			t.Fatalf("AddDnsRecordGroup failed: %v", err)
+		}
+		groupID = resp.Data.ID
+	})
+
+	// 2. Info
+	t.Run("GroupInfo", func(t *testing.T) {
+		info, err := service.GetDnsRecordGroupInfo(domainID, groupID)
+		if err != nil {
+			t.Fatalf("GetDnsRecordGroupInfo failed: %v", err)
+		}
+		if info.GroupName != groupName {
+			t.Errorf("Group name mismatch: expected %s, got %s", groupName, info.GroupName)
+		}
+	})
+
+	t.Run("ListAllGroups", func(t *testing.T) {
+		groups, err := service.ListAllDnsRecordGroups(DnsRecordGroupListRequest{DomainID: domainID, PerPage: 1})
+		if err != nil {
+			t.Fatalf("ListAllDnsRecordGroups failed: %v", err)
+		}
+		found := false
+		for _, g := range groups {
+			found = found || g.ID == groupID
+		}
+		if !found {
+			t.Errorf("Expected group %d in %v", groupID, groups)
+		}
+	})
+
+	// 3. Relation
+	t.Run("BindRecords", func(t *testing.T) {
+		err := service.BindRecordsToGroup(DnsRecordGroupRelationRequest{
+			DomainID:  domainID,
+			GroupID:   groupID,
+			RecordIDs: []int{recordID},
+			Action:    DnsRecordGroupRelationAdd,
+		})
+		if err != nil {
+			t.Fatalf("BindRecordsToGroup failed: %v", err)
+		}
+
+		records, err := service.ListAllDnsRecords(DnsRecordListRequest{DomainID: domainID, GroupID: groupID})
+		if err != nil {
+			t.Fatalf("ListAllDnsRecords failed: %v", err)
+		}
+		if len(records) != 1 || records[0].ID != recordID {
+			t.Errorf("Expected record %d in group, got %v", recordID, records)
+		}
+	})
+
+	// 4. Delete
+	t.Run("DeleteGroup", func(t *testing.T) {
+		if err := service.DeleteDnsRecordGroup(domainID, groupID); err != nil {
+			t.Fatalf("DeleteDnsRecordGroup failed: %v", err)
+		}
+	})
+}
@@ -34,3 +34,79 @@ func (s *SdnsService) DeleteDnsRecord(recordID, domainID int) error {
 	req := DnsRecordDeleteRequest{RecordID: recordID, DomainID: domainID}
 	return s.callAPI(context.Background(), "DELETE", EndpointDnsRecordDelete, req, nil)
 }
+
+// ListAllDnsRecords Lists every DNS domain record matching req, following all pages
+func (s *SdnsService) ListAllDnsRecords(req DnsRecordListRequest) ([]DnsRecord, error) {
+	if req.PerPage == 0 {
+		req.PerPage = 500
+	}
+
+	records := make([]DnsRecord, 0)
+	for page := 1; ; page++ {
+		req.Page = page
+		resp, err := s.ListDnsRecords(req)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, resp.List...)
+		// The server may cap per_page, so rely on total rather than the page size
+		if len(resp.List) == 0 || len(records) >= resp.Total {
+			return records, nil
+		}
+	}
+}
+
+// BatchAddDnsRecords Adds DNS domain records in one batch task and returns the task ID
+func (s *SdnsService) BatchAddDnsRecords(req DnsRecordBatchAddRequest) (int, error) {
+	var resp DnsRecordBatchResponse
+	err := s.callAPI(context.Background(), "POST", EndpointDnsRecordBatchAdd, req, &resp)
+	if err != nil {
+		return 0, err
+	}
+	return resp.Data.TaskID, nil
+}
+
+// BatchDeleteDnsRecords Deletes DNS domain records in one batch task and returns the task ID
+func (s *SdnsService) BatchDeleteDnsRecords(domainID int, recordIDs []int) (int, error) {
+	return s.batchDnsRecords(EndpointDnsRecordBatchDelete, domainID, recordIDs)
+}
+
+// BatchPauseDnsRecords Pauses DNS domain records in one batch task and returns the task ID
+func (s *SdnsService) BatchPauseDnsRecords(domainID int, recordIDs []int) (int, error) {
+	return s.batchDnsRecords(EndpointDnsRecordBatchPause, domainID, recordIDs)
+}
+
+// BatchEnableDnsRecords Enables DNS domain records in one batch task and returns the task ID
+func (s *SdnsService) BatchEnableDnsRecords(domainID int, recordIDs []int) (int, error) {
+	return s.batchDnsRecords(EndpointDnsRecordBatchEnable, domainID, recordIDs)
+}
+
+func (s *SdnsService) batchDnsRecords(endpoint string, domainID int, recordIDs []int) (int, error) {
+	req := DnsRecordBatchRequest{DomainID: domainID, RecordIDs: recordIDs}
+	var resp DnsRecordBatchResponse
+	err := s.callAPI(context.Background(), "POST", endpoint, req, &resp)
+	if err != nil {
+		return 0, err
+	}
+	return resp.Data.TaskID, nil
+}
+
+// ImportDnsRecords Imports DNS domain records in one batch task and returns the task ID
+func (s *SdnsService) ImportDnsRecords(req DnsRecordImportRequest) (int, error) {
+	var resp DnsRecordBatchResponse
+	err := s.callAPI(context.Background(), "POST", EndpointDnsRecordImport, req, &resp)
+	if err != nil {
+		return 0, err
+	}
+	return resp.Data.TaskID, nil
+}
+
+// ListDnsRecordTypes Lists the record types supported by the DNS service
+func (s *SdnsService) ListDnsRecordTypes() ([]DnsRecordTypeInfo, error) {
+	var resp DnsRecordTypesResponse
+	err := s.callAPI(context.Background(), "GET", EndpointDnsRecordTypes, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
@@ -96,3 +96,78 @@ func TestSdnsService_DnsRecordCRUD(t *testing.T) {
 		}
 	})
 }
+
+func TestSdnsService_DnsRecordBatch(t *testing.T) {
+	if !isIntegrationTest() {
+		t.Skip("Skipping integration test")
+	}
+
+	client := createTestClient(t)
+	service := NewSdnsService(client)
+
+	domainName := "test-dns-record-batch-domain.com"
+	service.AddDnsDomain(domainName)
+	defer func() {
+		resp, _ := service.ListDnsDomains(DnsDomainListRequest{Domain: domainName})
+		var ids []int
+		for _, d := range resp.List {
+			if d.Domain == domainName {
+				ids = append(ids, d.ID)
+			}
+		}
+		if len(ids) > 0 {
+			service.DeleteDnsDomain(ids)
+		}
+	}()
+
+	resp, _ := service.ListDnsDomains(DnsDomainListRequest{Domain: domainName})
+	if len(resp.List) == 0 {
+		t.Fatalf("Failed to prepare test domain")
+	}
+	domainID := resp.List[0].ID
+
+	t.Run("BatchAddRecords", func(t *testing.T) {
+		req := DnsRecordBatchAddRequest{
+			DomainID: domainID,
+			Records: []DnsRecordBatchItem{
+				{RecordName: "a", RecordType: "A", RecordView: "any", RecordValue: "1.2.3.4", RecordTTL: 600},
+				{RecordName: "b", RecordType: "A", RecordView: "any", RecordValue: "1.2.3.5", RecordTTL: 600},
+			},
+		}
+		if _, err := service.BatchAddDnsRecords(req); err != nil {
+			t.Fatalf("BatchAddDnsRecords failed: %v", err)
+		}
+	})
+
+	t.Run("ListAllRecordsAndBatchDelete", func(t *testing.T) {
+		records, err := service.ListAllDnsRecords(DnsRecordListRequest{DomainID: domainID, PerPage: 1})
+		if err != nil {
+			t.Fatalf("ListAllDnsRecords failed: %v", err)
+		}
+
+		var ids []int
+		for _, r := range records {
+			if r.Name == "a" || r.Name == "b" {
+				ids = append(ids, r.ID)
+			}
+		}
+		if len(ids) == 0 {
+			t.Skip("Batch added records are not visible yet")
+		}
+		if _, err := service.BatchDeleteDnsRecords(domainID, ids); err != nil {
+			t.Fatalf("BatchDeleteDnsRecords failed: %v", err)
+		}
+	})
+
+	t.Run("ListBatchTasks", func(t *testing.T) {
+		tasks, err := service.ListDnsBatchTasks(DnsBatchTaskListRequest{DomainID: domainID})
+		if err != nil {
+			t.Fatalf("ListDnsBatchTasks failed: %v", err)
+		}
+		if len(tasks.List) > 0 {
+			if _, err := service.GetDnsBatchTaskDetail(tasks.List[0].ID); err != nil {
+				t.Fatalf("GetDnsBatchTaskDetail failed: %v", err)
+			}
+		}
+	})
+}
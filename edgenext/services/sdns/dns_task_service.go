@@ -0,0 +1,26 @@
+package sdns
+
+import (
+	"context"
+)
+
+// ListDnsBatchTasks Lists DNS batch tasks, newest first
+func (s *SdnsService) ListDnsBatchTasks(req DnsBatchTaskListRequest) (*DnsBatchTaskListData, error) {
+	var resp DnsBatchTaskListResponse
+	err := s.callAPI(context.Background(), "GET", EndpointDnsBatchTaskList, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// GetDnsBatchTaskDetail Gets a DNS batch task with the result of each item
+func (s *SdnsService) GetDnsBatchTaskDetail(taskID int) (*DnsBatchTaskDetail, error) {
+	req := DnsBatchTaskDetailRequest{TaskID: taskID}
+	var resp DnsBatchTaskDetailResponse
+	err := s.callAPI(context.Background(), "GET", EndpointDnsBatchTaskDetail, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
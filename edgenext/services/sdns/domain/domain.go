@@ -9,7 +9,8 @@ import (
 // Resources returns all domain-related resources
 func Resources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"edgenext_sdns_domain": resource.ResourceEdgenextDnsDomain(),
+		"edgenext_sdns_domain":  resource.ResourceEdgenextDnsDomain(),
+		"edgenext_sdns_domains": resource.ResourceEdgenextDnsDomains(),
 	}
 }
 
@@ -0,0 +1,387 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dnsDomainBatchSize is the number of domains sent in one batch add or delete call
+const dnsDomainBatchSize = 100
+
+var dnsDomainNamePattern = regexp.MustCompile(`^[a-z0-9_-]+(\.[a-z0-9_-]+)+$`)
+
+// ResourceEdgenextDnsDomains returns the resource adding and removing many domains in batches
+func ResourceEdgenextDnsDomains() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDnsDomainsCreate,
+		ReadContext:   resourceDnsDomainsRead,
+		UpdateContext: resourceDnsDomainsUpdate,
+		DeleteContext: resourceDnsDomainsDelete,
+
+		CustomizeDiff: resourceDnsDomainsCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"domains": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(dnsDomainNamePattern, "must be a lowercase domain name without the trailing dot"),
+				},
+				Description: "The domain names to add to DNS. Removing a domain from the set deletes it",
+			},
+			"group_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The ID of the domain group to assign the domains to",
+			},
+			// Computed fields
+			"domain_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The IDs of the added domains, keyed by domain name",
+			},
+			"failed_domains": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The reason each domain failed in the last apply, keyed by domain name. Failed domains are retried on the next apply",
+			},
+		},
+	}
+}
+
+// resourceDnsDomainsCustomizeDiff marks the computed maps unknown when the domains or group change
+func resourceDnsDomainsCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.HasChange("domains") {
+		if err := d.SetNewComputed("domain_ids"); err != nil {
+			return err
+		}
+	}
+	if d.HasChanges("domains", "group_id") {
+		return d.SetNewComputed("failed_domains")
+	}
+	return nil
+}
+
+func resourceDnsDomainsCreate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	domains := expandStringSet(d.Get("domains").(*schema.Set))
+	groupID := d.Get("group_id").(int)
+
+	log.Printf("[INFO] Adding %d DNS domain(s)", len(domains))
+	ids, failed, err := addDnsDomains(service, domains)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("domains-%d", time.Now().Unix()))
+
+	if groupID != 0 {
+		bindDnsDomainsToGroup(service, groupID, sdns.DnsGroupRelationAdd, ids, failed)
+	}
+
+	return setDnsDomainsResult(d, ids, failed)
+}
+
+func resourceDnsDomainsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	log.Printf("[DEBUG] Reading DNS domains: %s", d.Id())
+	known := expandDnsDomainIDs(d.Get("domain_ids").(map[string]interface{}))
+	existing, err := service.ListAllDnsDomains(sdns.DnsDomainListRequest{})
+	if err != nil {
+		return diag.Errorf("failed to list DNS domains: %s", err)
+	}
+
+	// Only domains added by this resource are tracked. Domains deleted outside of Terraform, and
+	// those that failed to be added, drop out of the set so that the next apply adds them.
+	ids := make(map[string]int, len(known))
+	for _, info := range existing {
+		name := strings.ToLower(info.Domain)
+		if id, ok := known[name]; ok && id == info.ID {
+			ids[name] = info.ID
+		}
+	}
+	if len(ids) == 0 && len(known) > 0 {
+		log.Printf("[WARN] None of the DNS domains of %s exist anymore, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	domains := make([]string, 0, len(ids))
+	for name := range ids {
+		domains = append(domains, name)
+	}
+	d.Set("domains", domains)
+	d.Set("domain_ids", ids)
+
+	// A domain missing from the group shows up as a change of group_id, which assigns them again
+	if groupID := d.Get("group_id").(int); groupID != 0 {
+		members, err := service.ListAllDnsDomains(sdns.DnsDomainListRequest{GroupID: groupID})
+		if err != nil {
+			return diag.Errorf("failed to list DNS domains of group %d: %s", groupID, err)
+		}
+		inGroup := make(map[int]bool, len(members))
+		for _, info := range members {
+			inGroup[info.ID] = true
+		}
+		for name, id := range ids {
+			if !inGroup[id] {
+				log.Printf("[WARN] DNS domain %s is not in group %d", name, groupID)
+				d.Set("group_id", 0)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceDnsDomainsUpdate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	// The plan marks domain_ids unknown when the domains change, so the IDs come from prior state
+	oldIDs, _ := d.GetChange("domain_ids")
+	ids := expandDnsDomainIDs(oldIDs.(map[string]interface{}))
+	failed := make(map[string]string)
+
+	if d.HasChange("domains") {
+		oldSet, newSet := d.GetChange("domains")
+		removed := oldSet.(*schema.Set).Difference(newSet.(*schema.Set))
+		added := newSet.(*schema.Set).Difference(oldSet.(*schema.Set))
+
+		var removeIDs []int
+		for _, name := range expandStringSet(removed) {
+			if id, ok := ids[name]; ok {
+				removeIDs = append(removeIDs, id)
+			}
+		}
+		if len(removeIDs) > 0 {
+			log.Printf("[INFO] Deleting %d DNS domain(s)", len(removeIDs))
+			if err := deleteDnsDomains(service, removeIDs); err != nil {
+				return diag.FromErr(err)
+			}
+			for _, name := range expandStringSet(removed) {
+				delete(ids, name)
+			}
+		}
+
+		if added.Len() > 0 {
+			log.Printf("[INFO] Adding %d DNS domain(s)", added.Len())
+			addedIDs, addFailed, err := addDnsDomains(service, expandStringSet(added))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			failed = addFailed
+
+			oldGroup, _ := d.GetChange("group_id")
+			if groupID := oldGroup.(int); groupID != 0 && !d.HasChange("group_id") {
+				bindDnsDomainsToGroup(service, groupID, sdns.DnsGroupRelationAdd, addedIDs, failed)
+			}
+			for name, id := range addedIDs {
+				ids[name] = id
+			}
+		}
+	}
+
+	if d.HasChange("group_id") {
+		oldGroup, newGroup := d.GetChange("group_id")
+		if groupID := oldGroup.(int); groupID != 0 {
+			bindDnsDomainsToGroup(service, groupID, sdns.DnsGroupRelationDelete, ids, failed)
+		}
+		if groupID := newGroup.(int); groupID != 0 {
+			bindDnsDomainsToGroup(service, groupID, sdns.DnsGroupRelationAdd, ids, failed)
+		}
+	}
+
+	return setDnsDomainsResult(d, ids, failed)
+}
+
+func resourceDnsDomainsDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	ids := expandDnsDomainIDs(d.Get("domain_ids").(map[string]interface{}))
+	domainIDs := make([]int, 0, len(ids))
+	for _, id := range ids {
+		domainIDs = append(domainIDs, id)
+	}
+
+	log.Printf("[INFO] Deleting %d DNS domain(s)", len(domainIDs))
+	if err := deleteDnsDomains(service, domainIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// addDnsDomains adds the domains in batches. It returns the IDs of the added domains and the reason
+// each of the others failed; only a failing call for every batch is an error.
+func addDnsDomains(service *sdns.SdnsService, domains []string) (map[string]int, map[string]string, error) {
+	sort.Strings(domains)
+	ids := make(map[string]int, len(domains))
+	failed := make(map[string]string)
+
+	var lastErr error
+	for start := 0; start < len(domains); start += dnsDomainBatchSize {
+		end := start + dnsDomainBatchSize
+		if end > len(domains) {
+			end = len(domains)
+		}
+		batch := domains[start:end]
+
+		result, err := service.BatchAddDnsDomains(batch)
+		if err != nil {
+			log.Printf("[WARN] Failed to add a batch of %d DNS domain(s): %v", len(batch), err)
+			lastErr = err
+			for _, name := range batch {
+				failed[name] = err.Error()
+			}
+			continue
+		}
+		for _, item := range result.Fail {
+			failed[strings.ToLower(item.Domain)] = item.Message
+		}
+	}
+	if lastErr != nil && len(failed) == len(domains) {
+		return nil, nil, fmt.Errorf("failed to add DNS domains: %w", lastErr)
+	}
+
+	// The batch response does not reliably carry the IDs, so they are looked up
+	wanted := make(map[string]bool, len(domains))
+	for _, name := range domains {
+		if _, ok := failed[name]; !ok {
+			wanted[name] = true
+		}
+	}
+	existing, err := service.ListAllDnsDomains(sdns.DnsDomainListRequest{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list DNS domains: %w", err)
+	}
+	for _, info := range existing {
+		name := strings.ToLower(info.Domain)
+		if wanted[name] {
+			ids[name] = info.ID
+		}
+	}
+	for name := range wanted {
+		if _, ok := ids[name]; !ok {
+			failed[name] = "domain not found after adding it"
+		}
+	}
+
+	for name, reason := range failed {
+		log.Printf("[WARN] Failed to add DNS domain %s: %s", name, reason)
+	}
+	return ids, failed, nil
+}
+
+// deleteDnsDomains deletes the domains in batches
+func deleteDnsDomains(service *sdns.SdnsService, domainIDs []int) error {
+	sort.Ints(domainIDs)
+	for start := 0; start < len(domainIDs); start += dnsDomainBatchSize {
+		end := start + dnsDomainBatchSize
+		if end > len(domainIDs) {
+			end = len(domainIDs)
+		}
+		if err := service.DeleteDnsDomain(domainIDs[start:end]); err != nil {
+			return fmt.Errorf("failed to delete DNS domains: %w", err)
+		}
+	}
+	return nil
+}
+
+// bindDnsDomainsToGroup adds the domains to or removes them from the group. A failing call is
+// recorded for each of its domains in failed rather than aborting the apply.
+func bindDnsDomainsToGroup(service *sdns.SdnsService, groupID int, action string, ids map[string]int, failed map[string]string) {
+	names := make([]string, 0, len(ids))
+	for name := range ids {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for start := 0; start < len(names); start += dnsDomainBatchSize {
+		end := start + dnsDomainBatchSize
+		if end > len(names) {
+			end = len(names)
+		}
+		batch := names[start:end]
+		domainIDs := make([]int, 0, len(batch))
+		for _, name := range batch {
+			domainIDs = append(domainIDs, ids[name])
+		}
+
+		err := service.BindDomainsToGroup(sdns.DnsGroupDomainSaveRequest{GroupID: groupID, DomainIDs: domainIDs, Action: action})
+		if err != nil {
+			log.Printf("[WARN] Failed to %s %d DNS domain(s) in group %d: %v", action, len(batch), groupID, err)
+			for _, name := range batch {
+				failed[name] = fmt.Sprintf("failed to %s in group %d: %v", action, groupID, err)
+			}
+		}
+	}
+}
+
+// setDnsDomainsResult stores the added and failed domains and returns a warning for each failed one
+func setDnsDomainsResult(d *schema.ResourceData, ids map[string]int, failed map[string]string) diag.Diagnostics {
+	if err := d.Set("domain_ids", ids); err != nil {
+		return diag.Errorf("error setting domain_ids: %s", err)
+	}
+	if err := d.Set("failed_domains", failed); err != nil {
+		return diag.Errorf("error setting failed_domains: %s", err)
+	}
+	return dnsDomainsFailureWarnings(failed)
+}
+
+// dnsDomainsFailureWarnings returns a warning for each failed domain, sorted by domain name
+func dnsDomainsFailureWarnings(failed map[string]string) diag.Diagnostics {
+	names := make([]string, 0, len(failed))
+	for name := range failed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var diags diag.Diagnostics
+	for _, name := range names {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("DNS domain %s failed", name),
+			Detail:   fmt.Sprintf("%s. The domain is retried on the next apply.", strings.TrimSuffix(failed[name], ".")),
+		})
+	}
+	return diags
+}
+
+func expandDnsDomainIDs(m map[string]interface{}) map[string]int {
+	ids := make(map[string]int, len(m))
+	for name, id := range m {
+		ids[name] = id.(int)
+	}
+	return ids
+}
+
+func expandStringSet(set *schema.Set) []string {
+	list := set.List()
+	vs := make([]string, 0, len(list))
+	for _, v := range list {
+		vs = append(vs, v.(string))
+	}
+	return vs
+}
@@ -0,0 +1,319 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAddDnsDomains(t *testing.T) {
+	many := make([]string, 0, 150)
+	for i := 0; i < 150; i++ {
+		many = append(many, fmt.Sprintf("d%03d.example.com", i))
+	}
+
+	tests := []struct {
+		name          string
+		domains       []string
+		setup         func(f *fakeSdnsAPI)
+		wantAdded     int
+		wantFailed    map[string]string
+		wantBatchAdds int
+		wantErr       bool
+	}{
+		{
+			name:          "all added",
+			domains:       []string{"b.example.com", "a.example.com"},
+			wantAdded:     2,
+			wantFailed:    map[string]string{},
+			wantBatchAdds: 1,
+		},
+		{
+			name:    "domains rejected by the batch",
+			domains: []string{"a.example.com", "taken.example.com"},
+			setup: func(f *fakeSdnsAPI) {
+				f.rejectDomains["taken.example.com"] = "domain already exists"
+			},
+			wantAdded:     1,
+			wantFailed:    map[string]string{"taken.example.com": "domain already exists"},
+			wantBatchAdds: 1,
+		},
+		{
+			name:    "domain missing after adding it",
+			domains: []string{"a.example.com", "ghost.example.com"},
+			setup: func(f *fakeSdnsAPI) {
+				f.hideDomains["ghost.example.com"] = true
+			},
+			wantAdded:     1,
+			wantFailed:    map[string]string{"ghost.example.com": "domain not found after adding it"},
+			wantBatchAdds: 1,
+		},
+		{
+			name:    "failing batch only fails its own domains",
+			domains: many,
+			setup: func(f *fakeSdnsAPI) {
+				f.failBatchWith = many[0]
+			},
+			wantAdded:     50,
+			wantBatchAdds: 2,
+		},
+		{
+			name:    "every batch failing is an error",
+			domains: []string{"a.example.com"},
+			setup: func(f *fakeSdnsAPI) {
+				f.failBatchWith = "a.example.com"
+			},
+			wantBatchAdds: 1,
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeSdnsAPI()
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+			service := sdns.NewSdnsService(f.start(t))
+
+			ids, failed, err := addDnsDomains(service, append([]string(nil), tt.domains...))
+			if f.batchAdds != tt.wantBatchAdds {
+				t.Errorf("batch add calls = %d, want %d", f.batchAdds, tt.wantBatchAdds)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("addDnsDomains() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("addDnsDomains() error = %v", err)
+			}
+
+			if len(ids) != tt.wantAdded {
+				t.Errorf("added %d domain(s), want %d", len(ids), tt.wantAdded)
+			}
+			if len(ids)+len(failed) != len(tt.domains) {
+				t.Errorf("added %d and failed %d domain(s), want %d in total", len(ids), len(failed), len(tt.domains))
+			}
+			for name, id := range ids {
+				if f.domains[name] != id {
+					t.Errorf("domain %s has ID %d, want %d", name, id, f.domains[name])
+				}
+			}
+			if tt.wantFailed != nil && !reflect.DeepEqual(failed, tt.wantFailed) {
+				t.Errorf("failed = %v, want %v", failed, tt.wantFailed)
+			}
+			if f.failBatchWith != "" {
+				for _, name := range tt.domains[:dnsDomainBatchSize] {
+					if failed[name] != "API error: batch rejected (code: 0)" {
+						t.Errorf("failed[%s] = %q, want the batch error", name, failed[name])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDnsDomainsFailureWarnings(t *testing.T) {
+	tests := []struct {
+		name   string
+		failed map[string]string
+		want   diag.Diagnostics
+	}{
+		{
+			name:   "no failures",
+			failed: map[string]string{},
+			want:   nil,
+		},
+		{
+			name: "one warning per domain sorted by name",
+			failed: map[string]string{
+				"b.example.com": "domain already exists.",
+				"a.example.com": "failed to add in group 7: locked",
+			},
+			want: diag.Diagnostics{
+				{
+					Severity: diag.Warning,
+					Summary:  "DNS domain a.example.com failed",
+					Detail:   "failed to add in group 7: locked. The domain is retried on the next apply.",
+				},
+				{
+					Severity: diag.Warning,
+					Summary:  "DNS domain b.example.com failed",
+					Detail:   "domain already exists. The domain is retried on the next apply.",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dnsDomainsFailureWarnings(tt.failed); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dnsDomainsFailureWarnings() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceDnsDomainsUpdate(t *testing.T) {
+	tests := []struct {
+		name         string
+		setup        func(f *fakeSdnsAPI)
+		oldDomains   []interface{}
+		oldGroupID   int
+		newDomains   []interface{}
+		newGroupID   int
+		wantDomains  []string
+		wantGroups   map[int][]string
+		wantFailed   []string
+		wantDeleted  int
+		wantWarnings int
+	}{
+		{
+			name:        "domains are added and removed",
+			oldDomains:  []interface{}{"a.example.com", "b.example.com"},
+			newDomains:  []interface{}{"a.example.com", "c.example.com"},
+			wantDomains: []string{"a.example.com", "c.example.com"},
+			wantDeleted: 1,
+		},
+		{
+			name:        "added domains join the unchanged group",
+			oldDomains:  []interface{}{"a.example.com"},
+			oldGroupID:  7,
+			newDomains:  []interface{}{"a.example.com", "c.example.com"},
+			newGroupID:  7,
+			wantDomains: []string{"a.example.com", "c.example.com"},
+			wantGroups:  map[int][]string{7: {"a.example.com", "c.example.com"}},
+		},
+		{
+			name:        "a group change moves every domain",
+			oldDomains:  []interface{}{"a.example.com", "b.example.com"},
+			oldGroupID:  7,
+			newDomains:  []interface{}{"a.example.com", "b.example.com"},
+			newGroupID:  8,
+			wantDomains: []string{"a.example.com", "b.example.com"},
+			wantGroups:  map[int][]string{7: {}, 8: {"a.example.com", "b.example.com"}},
+		},
+		{
+			name: "a failing group call is a warning",
+			setup: func(f *fakeSdnsAPI) {
+				f.failGroups[9] = true
+			},
+			oldDomains:   []interface{}{"a.example.com"},
+			newDomains:   []interface{}{"a.example.com"},
+			newGroupID:   9,
+			wantDomains:  []string{"a.example.com"},
+			wantFailed:   []string{"a.example.com"},
+			wantWarnings: 1,
+		},
+		{
+			name: "a rejected domain is a warning",
+			setup: func(f *fakeSdnsAPI) {
+				f.rejectDomains["taken.example.com"] = "domain already exists"
+			},
+			oldDomains:   []interface{}{"a.example.com"},
+			newDomains:   []interface{}{"a.example.com", "taken.example.com"},
+			wantDomains:  []string{"a.example.com"},
+			wantFailed:   []string{"taken.example.com"},
+			wantWarnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeSdnsAPI()
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+			client := f.start(t)
+			ctx := context.Background()
+			r := ResourceEdgenextDnsDomains()
+
+			oldIDs := make(map[string]interface{})
+			for _, name := range tt.oldDomains {
+				id := f.add(name.(string))
+				oldIDs[name.(string)] = id
+				if tt.oldGroupID != 0 {
+					if f.groups[tt.oldGroupID] == nil {
+						f.groups[tt.oldGroupID] = make(map[int]bool)
+					}
+					f.groups[tt.oldGroupID][id] = true
+				}
+			}
+
+			prior := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+				"domains":  tt.oldDomains,
+				"group_id": tt.oldGroupID,
+			})
+			prior.SetId("domains-1")
+			if err := prior.Set("domain_ids", oldIDs); err != nil {
+				t.Fatalf("failed to set domain_ids: %v", err)
+			}
+
+			diff, err := r.Diff(ctx, prior.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+				"domains":  tt.newDomains,
+				"group_id": tt.newGroupID,
+			}), client)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+
+			state, diags := r.Apply(ctx, prior.State(), diff, client)
+			if diags.HasError() {
+				t.Fatalf("Apply() diagnostics = %v", diags)
+			}
+			if len(diags) != tt.wantWarnings {
+				t.Errorf("Apply() returned %d warning(s), want %d: %v", len(diags), tt.wantWarnings, diags)
+			}
+			if len(f.deleted) != tt.wantDeleted {
+				t.Errorf("deleted %d domain(s), want %d", len(f.deleted), tt.wantDeleted)
+			}
+
+			result := r.Data(state)
+			ids := expandDnsDomainIDs(result.Get("domain_ids").(map[string]interface{}))
+			domains := make([]string, 0, len(ids))
+			for name, id := range ids {
+				domains = append(domains, name)
+				if f.domains[name] != id {
+					t.Errorf("domain_ids[%s] = %d, want %d", name, id, f.domains[name])
+				}
+			}
+			sort.Strings(domains)
+			if !reflect.DeepEqual(domains, tt.wantDomains) {
+				t.Errorf("domain_ids has %v, want %v", domains, tt.wantDomains)
+			}
+
+			failed := make([]string, 0)
+			for name := range result.Get("failed_domains").(map[string]interface{}) {
+				failed = append(failed, name)
+			}
+			sort.Strings(failed)
+			if tt.wantFailed == nil {
+				tt.wantFailed = []string{}
+			}
+			if !reflect.DeepEqual(failed, tt.wantFailed) {
+				t.Errorf("failed_domains has %v, want %v", failed, tt.wantFailed)
+			}
+
+			for groupID, members := range tt.wantGroups {
+				for name := range f.domains {
+					want := false
+					for _, member := range members {
+						want = want || member == name
+					}
+					if got := f.inGroup(groupID, f.domains[name]); got != want {
+						t.Errorf("domain %s in group %d = %v, want %v", name, groupID, got, want)
+					}
+				}
+			}
+		})
+	}
+}
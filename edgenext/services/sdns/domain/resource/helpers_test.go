@@ -0,0 +1,172 @@
+package resource
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
+)
+
+// fakeSdnsAPI is an in-memory SDNS API serving the domain, group relation and name server endpoints
+type fakeSdnsAPI struct {
+	mu sync.Mutex
+
+	nextID  int
+	domains map[string]int       // domain name to ID
+	groups  map[int]map[int]bool // group ID to member domain IDs
+	servers map[int]*sdns.DnsDomainServers
+
+	rejectDomains map[string]string // domains reported in the Fail list of a batch add
+	failBatchWith string            // batch adds containing this domain fail as a whole
+	failGroups    map[int]bool      // group relation calls for these groups fail
+	hideDomains   map[string]bool   // domains added but missing from the list
+
+	batchAdds int
+	deleted   []int
+}
+
+func newFakeSdnsAPI() *fakeSdnsAPI {
+	return &fakeSdnsAPI{
+		nextID:        100,
+		domains:       make(map[string]int),
+		groups:        make(map[int]map[int]bool),
+		servers:       make(map[int]*sdns.DnsDomainServers),
+		rejectDomains: make(map[string]string),
+		failGroups:    make(map[int]bool),
+		hideDomains:   make(map[string]bool),
+	}
+}
+
+// start serves the fake API and returns a client pointing at it
+func (f *fakeSdnsAPI) start(t *testing.T) *connectivity.EdgeNextClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(server.Close)
+
+	config := &connectivity.Config{
+		AccessKey: "test-key",
+		SecretKey: "test-secret",
+		Endpoint:  server.URL,
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func (f *fakeSdnsAPI) add(name string) int {
+	f.nextID++
+	f.domains[name] = f.nextID
+	return f.nextID
+}
+
+func (f *fakeSdnsAPI) inGroup(groupID, domainID int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.groups[groupID][domainID]
+}
+
+func (f *fakeSdnsAPI) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body map[string]interface{}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == sdns.EndpointDnsDomainBatchAdd:
+		f.batchAdds++
+		names := make([]string, 0)
+		for _, v := range body["domains"].([]interface{}) {
+			names = append(names, v.(string))
+		}
+		for _, name := range names {
+			if name == f.failBatchWith {
+				writeFakeSdnsResponse(w, 0, "batch rejected", nil)
+				return
+			}
+		}
+		data := sdns.DnsDomainBatchAddData{}
+		for _, name := range names {
+			if reason, ok := f.rejectDomains[name]; ok {
+				data.Fail = append(data.Fail, sdns.DnsDomainBatchAddResult{Domain: name, Message: reason})
+				continue
+			}
+			data.Success = append(data.Success, sdns.DnsDomainBatchAddResult{ID: f.add(name), Domain: name})
+		}
+		writeFakeSdnsResponse(w, 1, "success", data)
+
+	case r.Method == http.MethodGet && r.URL.Path == sdns.EndpointDnsDomainList:
+		groupID, _ := strconv.Atoi(r.URL.Query().Get("group_id"))
+		list := make([]sdns.DnsDomainInfo, 0)
+		for name, id := range f.domains {
+			if f.hideDomains[name] || (groupID != 0 && !f.groups[groupID][id]) {
+				continue
+			}
+			list = append(list, sdns.DnsDomainInfo{ID: id, Domain: strings.ToUpper(name)})
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+		if page, _ := strconv.Atoi(r.URL.Query().Get("page")); page > 1 {
+			list = list[:0]
+		}
+		writeFakeSdnsResponse(w, 1, "success", sdns.DnsDomainListData{Total: len(list), List: list})
+
+	case r.Method == http.MethodDelete && r.URL.Path == sdns.EndpointDnsDomainBatchDelete:
+		for _, v := range body["domain_ids"].([]interface{}) {
+			id := int(v.(float64))
+			f.deleted = append(f.deleted, id)
+			for name, domainID := range f.domains {
+				if domainID == id {
+					delete(f.domains, name)
+				}
+			}
+		}
+		writeFakeSdnsResponse(w, 1, "success", nil)
+
+	case r.Method == http.MethodPost && r.URL.Path == sdns.EndpointDnsGroupRecordRelation:
+		groupID := int(body["group_id"].(float64))
+		if f.failGroups[groupID] {
+			writeFakeSdnsResponse(w, 0, "group is locked", nil)
+			return
+		}
+		if f.groups[groupID] == nil {
+			f.groups[groupID] = make(map[int]bool)
+		}
+		for _, v := range body["domain_ids"].([]interface{}) {
+			if body["action"] == sdns.DnsGroupRelationDelete {
+				delete(f.groups[groupID], int(v.(float64)))
+			} else {
+				f.groups[groupID][int(v.(float64))] = true
+			}
+		}
+		writeFakeSdnsResponse(w, 1, "success", nil)
+
+	case r.Method == http.MethodGet && r.URL.Path == sdns.EndpointDnsDomainServers:
+		domainID, _ := strconv.Atoi(r.URL.Query().Get("domain_id"))
+		servers, ok := f.servers[domainID]
+		if !ok {
+			writeFakeSdnsResponse(w, 0, "domain not found", nil)
+			return
+		}
+		writeFakeSdnsResponse(w, 1, "success", servers)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeFakeSdnsResponse(w http.ResponseWriter, code int, message string, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(connectivity.ScdnResponse{
+		Status: connectivity.ScdnStatus{Code: code, Message: message},
+		Data:   data,
+	})
+}
@@ -1,10 +1,12 @@
 package resource
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
@@ -15,11 +17,18 @@ func ResourceEdgenextDnsDomain() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceDnsDomainCreate,
 		Read:   resourceDnsDomainRead,
+		Update: resourceDnsDomainUpdate,
 		Delete: resourceDnsDomainDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: resourceDnsDomainCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(2 * time.Hour),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:        schema.TypeString,
@@ -27,11 +36,59 @@ func ResourceEdgenextDnsDomain() *schema.Resource {
 				ForceNew:    true,
 				Description: "The domain name to be added to DNS",
 			},
+			"wait_for_delegation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait until the registrar NS records of the domain point at the assigned name servers. The wait happens on the applies after the domain was created, as long as it is not delegated",
+			},
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "Status of the domain",
 			},
+			"name_servers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The authoritative name servers assigned to the domain, to be set at the registrar",
+			},
+			"current_name_servers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The name servers found in the registry when the delegation was last checked",
+			},
+			"delegated": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the domain is delegated to the assigned name servers",
+			},
+			"trust_status": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Trust status of the domain as reported by the API",
+			},
+			"trust_status_desc": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the delegation status",
+			},
+			"record_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of records of the domain",
+			},
+			"enable_record_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of enabled records of the domain",
+			},
+			"pause_record_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of paused records of the domain",
+			},
 		},
 	}
 }
@@ -49,6 +106,12 @@ func resourceDnsDomainCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.SetId(strconv.Itoa(resp.ID))
+
+	// The name servers to set at the registrar are only known now, so wait_for_delegation takes
+	// effect from the next apply on
+	if d.Get("wait_for_delegation").(bool) {
+		log.Printf("[INFO] DNS domain %s created, set its name servers at the registrar and apply again to wait for the delegation", domain)
+	}
 	return resourceDnsDomainRead(d, m)
 }
 
@@ -73,10 +136,52 @@ func resourceDnsDomainRead(d *schema.ResourceData, m interface{}) error {
 
 	d.Set("domain", info.Domain)
 	d.Set("status", strconv.Itoa(info.Status))
+	d.Set("trust_status", info.TrustStatus)
+	d.Set("trust_status_desc", info.TrustStatusDesc)
+
+	// The name servers and counts are informational, a failing lookup does not fail the read
+	servers, err := service.GetDnsDomainServers(id)
+	if err != nil {
+		log.Printf("[WARN] Failed to get name servers of DNS domain %d: %v", id, err)
+	} else {
+		d.Set("name_servers", servers.Servers)
+		d.Set("current_name_servers", servers.CurrentServers)
+	}
+	d.Set("delegated", sdns.IsDnsDomainDelegated(servers))
+
+	stat, err := service.GetDnsDomainStat(id)
+	if err != nil {
+		log.Printf("[WARN] Failed to get record counts of DNS domain %d: %v", id, err)
+	} else {
+		d.Set("record_count", stat.RecordCount)
+		d.Set("enable_record_count", stat.EnableRecordCount)
+		d.Set("pause_record_count", stat.PauseRecordCount)
+	}
 
 	return nil
 }
 
+func resourceDnsDomainUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid domain ID: %s", d.Id())
+	}
+
+	// wait_for_delegation is the only argument that can change in place
+	if d.Get("wait_for_delegation").(bool) {
+		ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+		defer cancel()
+		if err := waitForDnsDomainDelegation(ctx, service, id); err != nil {
+			return err
+		}
+	}
+
+	return resourceDnsDomainRead(d, m)
+}
+
 func resourceDnsDomainDelete(d *schema.ResourceData, m interface{}) error {
 	client := m.(*connectivity.EdgeNextClient)
 	service := sdns.NewSdnsService(client)
@@ -95,3 +200,37 @@ func resourceDnsDomainDelete(d *schema.ResourceData, m interface{}) error {
 	d.SetId("")
 	return nil
 }
+
+// resourceDnsDomainCustomizeDiff plans an update that waits for the delegation while
+// wait_for_delegation is set and the domain is not delegated yet
+func resourceDnsDomainCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.Get("wait_for_delegation").(bool) || d.Get("delegated").(bool) {
+		return nil
+	}
+	return d.SetNewComputed("delegated")
+}
+
+// waitForDnsDomainDelegation polls the domain until the registry delegates it to the assigned name
+// servers. Registrar changes take minutes to hours, so the domain is checked every 30 seconds.
+func waitForDnsDomainDelegation(ctx context.Context, service *sdns.SdnsService, domainID int) error {
+	var assigned []string
+	for {
+		servers, err := service.GetDnsDomainServers(domainID)
+		if err != nil {
+			log.Printf("[WARN] Failed to get name servers of DNS domain %d: %v", domainID, err)
+		} else {
+			assigned = servers.Servers
+			if sdns.IsDnsDomainDelegated(servers) {
+				log.Printf("[INFO] DNS domain %d is delegated", domainID)
+				return nil
+			}
+			log.Printf("[DEBUG] Waiting for delegation of DNS domain %d: registry has %s", domainID, strings.Join(servers.CurrentServers, ", "))
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout while waiting for DNS domain %d to be delegated to %s", domainID, strings.Join(assigned, ", "))
+		case <-time.After(30 * time.Second):
+		}
+	}
+}
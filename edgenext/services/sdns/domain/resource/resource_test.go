@@ -0,0 +1,85 @@
+package resource
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceDnsDomainCustomizeDiff(t *testing.T) {
+	tests := []struct {
+		name              string
+		waitForDelegation bool
+		delegated         bool
+		wantUpdate        bool
+	}{
+		{name: "not waiting", waitForDelegation: false, delegated: false, wantUpdate: false},
+		{name: "waiting for an undelegated domain", waitForDelegation: true, delegated: false, wantUpdate: true},
+		{name: "waiting for a delegated domain", waitForDelegation: true, delegated: true, wantUpdate: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := ResourceEdgenextDnsDomain()
+			config := map[string]interface{}{
+				"domain":              "example.com",
+				"wait_for_delegation": tt.waitForDelegation,
+			}
+
+			prior := schema.TestResourceDataRaw(t, r.Schema, config)
+			prior.SetId("101")
+			if err := prior.Set("delegated", tt.delegated); err != nil {
+				t.Fatalf("failed to set delegated: %v", err)
+			}
+
+			diff, err := r.Diff(context.Background(), prior.State(), terraform.NewResourceConfigRaw(config), nil)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			gotUpdate := diff != nil && diff.Attributes["delegated"] != nil && diff.Attributes["delegated"].NewComputed
+			if gotUpdate != tt.wantUpdate {
+				t.Errorf("planned update = %v, want %v (diff %v)", gotUpdate, tt.wantUpdate, diff)
+			}
+			if diff != nil && diff.RequiresNew() {
+				t.Errorf("diff requires replacement: %v", diff)
+			}
+		})
+	}
+}
+
+func TestWaitForDnsDomainDelegation(t *testing.T) {
+	assigned := []string{"ns1.example-dns.com", "ns2.example-dns.com"}
+	tests := []struct {
+		name    string
+		current []string
+		wantErr bool
+	}{
+		{name: "delegated", current: []string{"NS2.example-dns.com.", "ns1.example-dns.com"}, wantErr: false},
+		{name: "registry still has other name servers", current: []string{"ns1.registrar.com"}, wantErr: true},
+		{name: "registry has no name servers", current: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeSdnsAPI()
+			f.servers[101] = &sdns.DnsDomainServers{Servers: assigned, CurrentServers: tt.current}
+			service := sdns.NewSdnsService(f.start(t))
+
+			// A cancelled context allows a single check before the wait gives up
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err := waitForDnsDomainDelegation(ctx, service, 101)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("waitForDnsDomainDelegation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), strings.Join(assigned, ", ")) {
+				t.Errorf("waitForDnsDomainDelegation() error = %v, want it to name the assigned servers", err)
+			}
+		})
+	}
+}
@@ -19,6 +19,11 @@ func DataSourceEdgenextDnsRecord() *schema.Resource {
 				Required:    true,
 				Description: "Domain ID to list records for",
 			},
+			"group_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only list the records of this record group",
+			},
 			"records": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -73,18 +78,14 @@ func dataSourceDnsRecordRead(d *schema.ResourceData, m interface{}) error {
 	service := sdns.NewSdnsService(client)
 
 	domainID := d.Get("domain_id").(int)
-	req := sdns.DnsRecordListRequest{
-		DomainID: domainID,
-		PerPage:  1000,
-	}
-
-	resp, err := service.ListDnsRecords(req)
+	groupID := d.Get("group_id").(int)
+	list, err := service.ListAllDnsRecords(sdns.DnsRecordListRequest{DomainID: domainID, GroupID: groupID})
 	if err != nil {
 		return fmt.Errorf("failed to list DNS records: %w", err)
 	}
 
-	records := make([]map[string]interface{}, 0, len(resp.List))
-	for _, info := range resp.List {
+	records := make([]map[string]interface{}, 0, len(list))
+	for _, info := range list {
 		records = append(records, map[string]interface{}{
 			"id":     strconv.Itoa(info.ID),
 			"name":   info.Name,
@@ -102,6 +103,10 @@ func dataSourceDnsRecordRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("failed to set records: %w", err)
 	}
 
-	d.SetId(strconv.Itoa(domainID))
+	if groupID != 0 {
+		d.SetId(fmt.Sprintf("%d/%d", domainID, groupID))
+	} else {
+		d.SetId(strconv.Itoa(domainID))
+	}
 	return nil
 }
@@ -0,0 +1,97 @@
+package data
+
+import (
+	"fmt"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceEdgenextDnsRecordLines returns the data source listing the resolution lines usable as record views
+func DataSourceEdgenextDnsRecordLines() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDnsRecordLinesRead,
+
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Domain ID to list the lines for, the lines available depend on the plan of the domain. By default the lines of the account are listed",
+			},
+			"parent": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the lines directly under this line, e.g. the provinces of a country",
+			},
+			"codes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The codes of the lines, usable as record views",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"lines": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of lines",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"code": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The code of the line, used as record view",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the line",
+						},
+						"parent": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The code of the parent line",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDnsRecordLinesRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	domainID := d.Get("domain_id").(int)
+	list, err := service.ListDnsRecordLines(domainID)
+	if err != nil {
+		return fmt.Errorf("failed to list DNS record lines: %w", err)
+	}
+
+	parent, filterParent := d.GetOk("parent")
+	codes := make([]string, 0, len(list))
+	lines := make([]map[string]interface{}, 0, len(list))
+	for _, line := range list {
+		if filterParent && line.Parent != parent.(string) {
+			continue
+		}
+		codes = append(codes, line.Code)
+		lines = append(lines, map[string]interface{}{
+			"code":   line.Code,
+			"name":   line.Name,
+			"parent": line.Parent,
+		})
+	}
+
+	if err := d.Set("codes", codes); err != nil {
+		return fmt.Errorf("failed to set codes: %w", err)
+	}
+	if err := d.Set("lines", lines); err != nil {
+		return fmt.Errorf("failed to set lines: %w", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d/%s", domainID, d.Get("parent").(string)))
+	return nil
+}
@@ -0,0 +1,76 @@
+package data
+
+import (
+	"fmt"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceEdgenextDnsRecordTypes returns the data source listing the record types supported by the service
+func DataSourceEdgenextDnsRecordTypes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDnsRecordTypesRead,
+
+		Schema: map[string]*schema.Schema{
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The names of the record types",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"types": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of record types",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The record type, e.g. A",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the record type",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDnsRecordTypesRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	list, err := service.ListDnsRecordTypes()
+	if err != nil {
+		return fmt.Errorf("failed to list DNS record types: %w", err)
+	}
+
+	names := make([]string, 0, len(list))
+	types := make([]map[string]interface{}, 0, len(list))
+	for _, info := range list {
+		names = append(names, info.Type)
+		types = append(types, map[string]interface{}{
+			"type":        info.Type,
+			"description": info.Description,
+		})
+	}
+
+	if err := d.Set("names", names); err != nil {
+		return fmt.Errorf("failed to set names: %w", err)
+	}
+	if err := d.Set("types", types); err != nil {
+		return fmt.Errorf("failed to set types: %w", err)
+	}
+
+	d.SetId("sdns-record-types")
+	return nil
+}
@@ -0,0 +1,100 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceEdgenextDnsZoneExport returns the data source exporting the records of a domain as a zone file
+func DataSourceEdgenextDnsZoneExport() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDnsZoneExportRead,
+
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "Domain ID to export records for",
+			},
+			"view": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only export the records of this view. By default every view is exported",
+			},
+			"default_ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      600,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The $TTL of the zone file",
+			},
+			"default_view": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     sdns.DnsRecordViewDefault,
+				Description: "Records of other views are annotated with a view= comment",
+			},
+			// Computed fields
+			"domain": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The domain name",
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The zone file",
+			},
+			"record_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of records exported",
+			},
+		},
+	}
+}
+
+func dataSourceDnsZoneExportRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	domainID := d.Get("domain_id").(int)
+	domain, err := service.GetDnsDomainInfo(domainID)
+	if err != nil {
+		return fmt.Errorf("failed to get DNS domain info: %w", err)
+	}
+
+	// The zone file is built from the record listing rather than the records_export endpoint, whose
+	// response format is not documented, so that views can be filtered and annotated for the import
+	list, err := service.ListAllDnsRecords(sdns.DnsRecordListRequest{DomainID: domainID})
+	if err != nil {
+		return fmt.Errorf("failed to list DNS records: %w", err)
+	}
+
+	view := d.Get("view").(string)
+	records := make([]sdns.DnsRecord, 0, len(list))
+	for _, record := range list {
+		if view == "" || record.View == view {
+			records = append(records, record)
+		}
+	}
+
+	content := sdns.FormatZoneFile(domain.Domain, records, d.Get("default_ttl").(int), d.Get("default_view").(string))
+	if err := d.Set("domain", domain.Domain); err != nil {
+		return fmt.Errorf("failed to set domain: %w", err)
+	}
+	if err := d.Set("content", content); err != nil {
+		return fmt.Errorf("failed to set content: %w", err)
+	}
+	if err := d.Set("record_count", len(records)); err != nil {
+		return fmt.Errorf("failed to set record_count: %w", err)
+	}
+
+	d.SetId(strconv.Itoa(domainID))
+	return nil
+}
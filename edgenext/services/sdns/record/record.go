@@ -9,13 +9,19 @@ import (
 // Resources returns all record-related resources
 func Resources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"edgenext_sdns_record": resource.ResourceEdgenextDnsRecord(),
+		"edgenext_sdns_record":            resource.ResourceEdgenextDnsRecord(),
+		"edgenext_sdns_zone_records":      resource.ResourceEdgenextDnsZoneRecords(),
+		"edgenext_sdns_zone_import":       resource.ResourceEdgenextDnsZoneImport(),
+		"edgenext_sdns_cdn_cname_binding": resource.ResourceEdgenextDnsCdnCnameBinding(),
 	}
 }
 
 // DataSources returns all record-related data sources
 func DataSources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"edgenext_sdns_records": data.DataSourceEdgenextDnsRecord(),
+		"edgenext_sdns_records":      data.DataSourceEdgenextDnsRecord(),
+		"edgenext_sdns_zone_export":  data.DataSourceEdgenextDnsZoneExport(),
+		"edgenext_sdns_record_lines": data.DataSourceEdgenextDnsRecordLines(),
+		"edgenext_sdns_record_types": data.DataSourceEdgenextDnsRecordTypes(),
 	}
 }
@@ -0,0 +1,463 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/cdn"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Values of the platform argument of edgenext_sdns_cdn_cname_binding
+const (
+	cnameBindingPlatformScdn = "scdn"
+	cnameBindingPlatformCdn  = "cdn"
+)
+
+// ResourceEdgenextDnsCdnCnameBinding returns the resource pointing the SDNS record of an SCDN or CDN
+// domain at the CNAME assigned by the platform
+func ResourceEdgenextDnsCdnCnameBinding() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDnsCdnCnameBindingCreate,
+		ReadContext:   resourceDnsCdnCnameBindingRead,
+		UpdateContext: resourceDnsCdnCnameBindingUpdate,
+		DeleteContext: resourceDnsCdnCnameBindingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDnsCdnCnameBindingImport,
+		},
+
+		CustomizeDiff: resourceDnsCdnCnameBindingCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"platform": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{cnameBindingPlatformScdn, cnameBindingPlatformCdn}, false),
+				Description:  "The platform serving the domain, scdn or cdn",
+			},
+			"domain": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(v interface{}) string {
+					return strings.ToLower(strings.TrimSuffix(v.(string), "."))
+				},
+				Description: "The accelerated domain name, e.g. www.example.com. The SDNS zone owning it is found automatically",
+			},
+			"view": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     sdns.DnsRecordViewDefault,
+				Description: "View (line) of the CNAME record",
+			},
+			"ttl": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     600,
+				Description: "TTL of the CNAME record in seconds",
+			},
+			// Computed fields
+			"cname": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The CNAME assigned by the platform, the value of the record",
+			},
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the SDNS domain (zone) owning the domain",
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the SDNS zone owning the domain",
+			},
+			"record_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the record relative to the zone, @ for the apex",
+			},
+			"record_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the CNAME record",
+			},
+			"apex": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the domain is the apex of the zone, where the CNAME is flattened by the DNS service",
+			},
+			"platform_cname": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The CNAME the platform assigned when the binding was last refreshed, the record is updated when it differs from cname",
+			},
+		},
+	}
+}
+
+// resourceDnsCdnCnameBindingCustomizeDiff plans an update of the record when the platform has
+// assigned another CNAME, e.g. after an access mode switch. The CNAME is looked up by the refresh,
+// which warns when the lookup fails.
+func resourceDnsCdnCnameBindingCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	cname := d.Get("platform_cname").(string)
+	if cname != "" && cname != sdns.NormalizeDnsRecordValue("CNAME", d.Get("cname").(string)) {
+		return d.SetNew("cname", cname)
+	}
+	return nil
+}
+
+func resourceDnsCdnCnameBindingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	platform := d.Get("platform").(string)
+	domain := strings.ToLower(strings.TrimSuffix(d.Get("domain").(string), "."))
+	view := d.Get("view").(string)
+
+	cname, err := lookupPlatformCname(client, platform, domain)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	zone, name, err := locateDnsZone(service, domain)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	records, err := listDnsRecordsByName(service, zone.ID, name, view)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var existing *sdns.DnsRecord
+	var conflicts []string
+	for i, record := range records {
+		switch {
+		case strings.EqualFold(record.Type, "CNAME"):
+			existing = &records[i]
+		case name != "@":
+			conflicts = append(conflicts, fmt.Sprintf("%s %s", record.Type, record.Value))
+		}
+	}
+	if len(conflicts) > 0 {
+		return diag.Errorf("%s already has records that cannot coexist with a CNAME in view %s: %s", domain, view, strings.Join(conflicts, ", "))
+	}
+
+	recordID := 0
+	if existing != nil {
+		log.Printf("[INFO] Keeping existing CNAME record %d of %s", existing.ID, domain)
+		recordID = existing.ID
+		if sdns.NormalizeDnsRecordValue("CNAME", existing.Value) != cname || existing.TTL != d.Get("ttl").(int) {
+			if err := editCnameBindingRecord(service, zone.ID, recordID, name, view, cname, d.Get("ttl").(int)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	} else {
+		log.Printf("[INFO] Creating CNAME record %s in domain %d pointing at %s", name, zone.ID, cname)
+		recordID, err = service.AddDnsRecord(sdns.DnsRecordAddRequest{
+			DomainID:     zone.ID,
+			RecordName:   name,
+			RecordType:   "CNAME",
+			RecordView:   view,
+			RecordValue:  cname,
+			RecordTTL:    d.Get("ttl").(int),
+			RecordRemark: fmt.Sprintf("%s CNAME of %s", strings.ToUpper(platform), domain),
+		})
+		if err != nil {
+			return diag.Errorf("failed to create CNAME record: %s", err)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%d/%d", zone.ID, recordID))
+	d.Set("zone", zone.Domain)
+
+	diags := resourceDnsCdnCnameBindingRead(ctx, d, m)
+	return append(apexFlatteningWarning(domain, name), diags...)
+}
+
+func resourceDnsCdnCnameBindingRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	domainID, recordID, err := parseCnameBindingID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Reading CNAME binding record: %d in domain %d", recordID, domainID)
+	records, err := service.ListAllDnsRecords(sdns.DnsRecordListRequest{DomainID: domainID, RecordType: "CNAME"})
+	if err != nil {
+		return diag.Errorf("failed to list DNS records: %s", err)
+	}
+
+	var found *sdns.DnsRecord
+	for i := range records {
+		if records[i].ID == recordID {
+			found = &records[i]
+			break
+		}
+	}
+	if found == nil {
+		log.Printf("[WARN] CNAME binding record %d not found in domain %d", recordID, domainID)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("domain_id", domainID)
+	d.Set("record_id", recordID)
+	d.Set("record_name", found.Name)
+	d.Set("apex", found.Name == "@")
+	d.Set("view", found.View)
+	d.Set("ttl", found.TTL)
+	d.Set("cname", sdns.NormalizeDnsRecordValue("CNAME", found.Value))
+
+	// The record keeps its value when the lookup fails, a missing platform domain is only reported
+	domain := d.Get("domain").(string)
+	platformCname, err := lookupPlatformCname(client, d.Get("platform").(string), domain)
+	if err != nil {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Failed to look up the CNAME of %s", domain),
+				Detail:   fmt.Sprintf("%s. The record keeps pointing at %s until the CNAME assigned by the platform can be looked up.", err, d.Get("cname").(string)),
+			},
+		}
+	}
+	d.Set("platform_cname", platformCname)
+
+	return nil
+}
+
+func resourceDnsCdnCnameBindingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	domainID, recordID, err := parseCnameBindingID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("cname", "view", "ttl") {
+		log.Printf("[INFO] Updating CNAME binding record: %d in domain %d", recordID, domainID)
+		err := editCnameBindingRecord(service, domainID, recordID, d.Get("record_name").(string), d.Get("view").(string), d.Get("cname").(string), d.Get("ttl").(int))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceDnsCdnCnameBindingRead(ctx, d, m)
+}
+
+func resourceDnsCdnCnameBindingDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	domainID, recordID, err := parseCnameBindingID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[INFO] Deleting CNAME binding record: %d in domain %d", recordID, domainID)
+	if err := service.DeleteDnsRecord(recordID, domainID); err != nil {
+		return diag.Errorf("failed to delete CNAME record: %s", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceDnsCdnCnameBindingImport accepts <platform>/<domain>/<view> or <platform>/<domain>, the
+// existing CNAME record of the domain is looked up
+func resourceDnsCdnCnameBindingImport(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 && len(parts) != 3 {
+		return nil, fmt.Errorf("invalid import ID %q, expected <platform>/<domain> or <platform>/<domain>/<view>", d.Id())
+	}
+	platform, domain := parts[0], strings.ToLower(strings.TrimSuffix(parts[1], "."))
+	view := sdns.DnsRecordViewDefault
+	if len(parts) == 3 {
+		view = parts[2]
+	}
+
+	zone, name, err := locateDnsZone(service, domain)
+	if err != nil {
+		return nil, err
+	}
+	records, err := listDnsRecordsByName(service, zone.ID, name, view)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		if strings.EqualFold(record.Type, "CNAME") {
+			d.SetId(fmt.Sprintf("%d/%d", zone.ID, record.ID))
+			d.Set("platform", platform)
+			d.Set("domain", domain)
+			d.Set("zone", zone.Domain)
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+	return nil, fmt.Errorf("no CNAME record of %s in view %s found in zone %s", domain, view, zone.Domain)
+}
+
+// lookupPlatformCname returns the CNAME the platform assigned to domain, normalized
+func lookupPlatformCname(client *connectivity.EdgeNextClient, platform, domain string) (string, error) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	var cname string
+
+	switch platform {
+	case cnameBindingPlatformScdn:
+		resp, err := scdn.NewScdnService(client).ListDomains(scdn.DomainListRequest{Domain: domain, Page: 1, PageSize: 100})
+		if err != nil {
+			return "", fmt.Errorf("failed to query SCDN domain %s: %w", domain, err)
+		}
+		found := false
+		for _, info := range resp.Data.List {
+			if strings.EqualFold(info.Domain, domain) {
+				cname, found = info.Cname.Master, true
+				break
+			}
+		}
+		if !found {
+			return "", fmt.Errorf("SCDN domain %s not found", domain)
+		}
+	case cnameBindingPlatformCdn:
+		resp, err := cdn.NewCdnService(client).GetDomain(domain)
+		if err != nil {
+			return "", err
+		}
+		found := false
+		for _, info := range resp.Data {
+			if strings.EqualFold(info.Domain, domain) {
+				cname, found = info.Cname, true
+				break
+			}
+		}
+		if !found {
+			return "", fmt.Errorf("CDN domain %s not found", domain)
+		}
+	default:
+		return "", fmt.Errorf("unsupported platform %q", platform)
+	}
+
+	if cname == "" {
+		return "", fmt.Errorf("%s domain %s has no CNAME assigned yet", strings.ToUpper(platform), domain)
+	}
+	return sdns.NormalizeDnsRecordValue("CNAME", cname), nil
+}
+
+// locateDnsZone returns the SDNS domain owning domain and the record name relative to it
+func locateDnsZone(service *sdns.SdnsService, domain string) (*sdns.DnsDomainInfo, string, error) {
+	zones, err := service.ListAllDnsDomains(sdns.DnsDomainListRequest{})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list DNS domains: %w", err)
+	}
+	zone, name, ok := findDnsZone(zones, domain)
+	if !ok {
+		return nil, "", fmt.Errorf("no SDNS domain owns %s, add its zone first", domain)
+	}
+	return zone, name, nil
+}
+
+// findDnsZone returns the most specific zone that domain belongs to and the name of domain relative
+// to it, @ for the apex
+func findDnsZone(zones []sdns.DnsDomainInfo, domain string) (*sdns.DnsDomainInfo, string, bool) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	var best *sdns.DnsDomainInfo
+	bestZone := ""
+	for i := range zones {
+		zone := strings.ToLower(strings.TrimSuffix(zones[i].Domain, "."))
+		if domain != zone && !strings.HasSuffix(domain, "."+zone) {
+			continue
+		}
+		if best == nil || len(zone) > len(bestZone) {
+			best, bestZone = &zones[i], zone
+		}
+	}
+	if best == nil {
+		return nil, "", false
+	}
+
+	if domain == bestZone {
+		return best, "@", true
+	}
+	return best, strings.TrimSuffix(domain, "."+bestZone), true
+}
+
+// listDnsRecordsByName returns the records named name in view, of any type, sorted by ID
+func listDnsRecordsByName(service *sdns.SdnsService, domainID int, name, view string) ([]sdns.DnsRecord, error) {
+	records, err := service.ListAllDnsRecords(sdns.DnsRecordListRequest{DomainID: domainID, RecordName: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DNS records: %w", err)
+	}
+
+	matches := make([]sdns.DnsRecord, 0, len(records))
+	for _, record := range records {
+		if strings.EqualFold(record.Name, name) && record.View == view {
+			matches = append(matches, record)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+	return matches, nil
+}
+
+func editCnameBindingRecord(service *sdns.SdnsService, domainID, recordID int, name, view, cname string, ttl int) error {
+	err := service.UpdateDnsRecord(sdns.DnsRecordEditRequest{
+		RecordID:    recordID,
+		DomainID:    domainID,
+		RecordName:  name,
+		RecordType:  "CNAME",
+		RecordView:  view,
+		RecordValue: cname,
+		RecordTTL:   ttl,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update CNAME record: %w", err)
+	}
+	return nil
+}
+
+// apexFlatteningWarning warns that a CNAME at the zone apex is flattened
+func apexFlatteningWarning(domain, name string) diag.Diagnostics {
+	if name != "@" {
+		return nil
+	}
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "CNAME at the zone apex is flattened",
+			Detail:   fmt.Sprintf("%s is the apex of its zone, where a CNAME cannot coexist with the SOA and NS records. The DNS service answers queries for it with the addresses the CNAME resolves to instead of the CNAME itself.", domain),
+		},
+	}
+}
+
+// parseCnameBindingID splits an ID of the form <domain_id>/<record_id>
+func parseCnameBindingID(id string) (int, int, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) == 2 {
+		domainID, err := strconv.Atoi(parts[0])
+		if err == nil {
+			recordID, err := strconv.Atoi(parts[1])
+			if err == nil {
+				return domainID, recordID, nil
+			}
+		}
+	}
+	return 0, 0, fmt.Errorf("invalid CNAME binding ID %q, expected <domain_id>/<record_id>", id)
+}
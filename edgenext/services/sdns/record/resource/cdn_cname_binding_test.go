@@ -0,0 +1,62 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestFindDnsZone(t *testing.T) {
+	zones := []sdns.DnsDomainInfo{
+		{ID: 1, Domain: "example.com"},
+		{ID: 2, Domain: "shop.example.com"},
+		{ID: 3, Domain: "ample.com"},
+	}
+	cases := []struct {
+		domain string
+		zoneID int
+		name   string
+		ok     bool
+	}{
+		{"www.example.com", 1, "www", true},
+		{"Example.com.", 1, "@", true},
+		{"cdn.shop.example.com", 2, "cdn", true},
+		{"shop.example.com", 2, "@", true},
+		{"a.b.example.com", 1, "a.b", true},
+		{"www.example.net", 0, "", false},
+		{"xample.com", 0, "", false},
+	}
+	for _, c := range cases {
+		zone, name, ok := findDnsZone(zones, c.domain)
+		if ok != c.ok {
+			t.Errorf("findDnsZone(%q) ok = %v, want %v", c.domain, ok, c.ok)
+			continue
+		}
+		if ok && (zone.ID != c.zoneID || name != c.name) {
+			t.Errorf("findDnsZone(%q) = %d, %q, want %d, %q", c.domain, zone.ID, name, c.zoneID, c.name)
+		}
+	}
+}
+
+func TestParseCnameBindingID(t *testing.T) {
+	domainID, recordID, err := parseCnameBindingID("12/34")
+	if err != nil || domainID != 12 || recordID != 34 {
+		t.Errorf("parseCnameBindingID(12/34) = %d, %d, %v", domainID, recordID, err)
+	}
+	for _, id := range []string{"12", "scdn/www.example.com", "12/34/56"} {
+		if _, _, err := parseCnameBindingID(id); err == nil {
+			t.Errorf("parseCnameBindingID(%q) succeeded, want error", id)
+		}
+	}
+}
+
+func TestApexFlatteningWarning(t *testing.T) {
+	if diags := apexFlatteningWarning("www.example.com", "www"); len(diags) != 0 {
+		t.Errorf("apexFlatteningWarning(www) = %v, want none", diags)
+	}
+	diags := apexFlatteningWarning("example.com", "@")
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("apexFlatteningWarning(@) = %v, want one warning", diags)
+	}
+}
@@ -0,0 +1,221 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
+)
+
+// dnsRecordBatchSize is the number of records sent in one batch call
+const dnsRecordBatchSize = 100
+
+// dnsBatchApplied reports whether a listing of the domain's records shows the effect of a batch call
+type dnsBatchApplied func(records []sdns.DnsRecord) bool
+
+// runDnsBatchTask submits a batch call and waits for the task it starts. kind names the operation in
+// logs and errors. When the call returns no task ID, the records of the domain are listed until
+// applied reports the batch has taken effect.
+func runDnsBatchTask(ctx context.Context, service *sdns.SdnsService, domainID int, kind string, submit func() (int, error), applied dnsBatchApplied) error {
+	taskID, err := submit()
+	if err != nil {
+		return fmt.Errorf("failed to %s DNS records: %w", kind, err)
+	}
+	if taskID == 0 {
+		log.Printf("[DEBUG] DNS batch %s for domain %d returned no task ID, checking the records instead", kind, domainID)
+		return waitForDnsBatchRecords(ctx, service, domainID, kind, applied)
+	}
+	return waitForDnsBatchTask(ctx, service, kind, taskID)
+}
+
+// waitForDnsBatchTask polls the task until it has finished and returns an error listing the records
+// that failed
+func waitForDnsBatchTask(ctx context.Context, service *sdns.SdnsService, kind string, taskID int) error {
+	for {
+		detail, err := service.GetDnsBatchTaskDetail(taskID)
+		if err != nil {
+			log.Printf("[WARN] Failed to query DNS batch task %d: %v", taskID, err)
+		} else if detail.Status == sdns.DnsBatchTaskStatusFinished || detail.Status == sdns.DnsBatchTaskStatusFailed {
+			return dnsBatchTaskError(kind, taskID, detail)
+		} else {
+			log.Printf("[DEBUG] Waiting for DNS batch %s task %d: %d of %d records done", kind, taskID, detail.SuccessCount+detail.FailCount, detail.Total)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout while waiting for DNS batch %s task %d to complete", kind, taskID)
+		case <-time.After(10 * time.Second):
+		}
+	}
+}
+
+// waitForDnsBatchRecords lists the records of the domain until applied reports the batch has taken effect
+func waitForDnsBatchRecords(ctx context.Context, service *sdns.SdnsService, domainID int, kind string, applied dnsBatchApplied) error {
+	for {
+		records, err := service.ListAllDnsRecords(sdns.DnsRecordListRequest{DomainID: domainID})
+		if err != nil {
+			log.Printf("[WARN] Failed to list DNS records of domain %d: %v", domainID, err)
+		} else if applied(records) {
+			return nil
+		} else {
+			log.Printf("[DEBUG] Waiting for DNS batch %s of domain %d to take effect", kind, domainID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout while waiting for DNS batch %s of domain %d to take effect, the API returned no task to check", kind, domainID)
+		case <-time.After(10 * time.Second):
+		}
+	}
+}
+
+// dnsRecordsDeleted reports a batch as applied once none of the records exist
+func dnsRecordsDeleted(recordIDs []int) dnsBatchApplied {
+	return func(records []sdns.DnsRecord) bool {
+		deleted := make(map[int]bool, len(recordIDs))
+		for _, id := range recordIDs {
+			deleted[id] = true
+		}
+		for _, record := range records {
+			if deleted[record.ID] {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// dnsRecordsInStatus reports a batch as applied once every record has the status
+func dnsRecordsInStatus(recordIDs []int, status int) dnsBatchApplied {
+	return func(records []sdns.DnsRecord) bool {
+		statuses := make(map[int]int, len(records))
+		for _, record := range records {
+			statuses[record.ID] = record.Status
+		}
+		for _, id := range recordIDs {
+			if current, ok := statuses[id]; !ok || current != status {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// dnsRecordsAdded reports a batch as applied once every submitted record exists
+func dnsRecordsAdded(items []sdns.DnsRecordBatchItem) dnsBatchApplied {
+	return func(records []sdns.DnsRecord) bool {
+		existing := make(map[string]int, len(records))
+		for _, record := range records {
+			existing[zoneRecordKey(record.Name, record.Type, record.View, record.Value)]++
+		}
+		for _, item := range items {
+			key := zoneRecordKey(item.RecordName, item.RecordType, item.RecordView, item.RecordValue)
+			if existing[key] == 0 {
+				return false
+			}
+			existing[key]--
+		}
+		return true
+	}
+}
+
+// dnsBatchTaskError returns an error listing the failed items of a finished task, nil if all succeeded
+func dnsBatchTaskError(kind string, taskID int, detail *sdns.DnsBatchTaskDetail) error {
+	var failed []string
+	for _, item := range detail.List {
+		if item.Status == sdns.DnsBatchTaskStatusFailed {
+			failed = append(failed, fmt.Sprintf("%s %s %s (%s)", item.RecordName, item.RecordType, item.RecordValue, item.Message))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("DNS batch %s task %d failed for %d record(s):\n  %s", kind, taskID, len(failed), strings.Join(failed, "\n  "))
+	}
+	if detail.Status == sdns.DnsBatchTaskStatusFailed || detail.FailCount > 0 {
+		return fmt.Errorf("DNS batch %s task %d failed for %d of %d record(s)", kind, taskID, detail.FailCount, detail.Total)
+	}
+	return nil
+}
+
+// chunkInts splits ids into slices of at most size elements
+func chunkInts(ids []int, size int) [][]int {
+	chunks := make([][]int, 0, (len(ids)+size-1)/size)
+	for start := 0; start < len(ids); start += size {
+		end := start + size
+		if end > len(ids) {
+			end = len(ids)
+		}
+		chunks = append(chunks, ids[start:end])
+	}
+	return chunks
+}
+
+// resolveDnsDomainID returns the ID of a domain given by ID or by name
+func resolveDnsDomainID(service *sdns.SdnsService, domain string) (int, error) {
+	if domainID, err := strconv.Atoi(domain); err == nil {
+		return domainID, nil
+	}
+
+	name := strings.ToLower(strings.TrimSuffix(domain, "."))
+	resp, err := service.ListDnsDomains(sdns.DnsDomainListRequest{Domain: name, PerPage: 100})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list DNS domains: %w", err)
+	}
+	for _, info := range resp.List {
+		if strings.EqualFold(info.Domain, name) {
+			return info.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("DNS domain %s not found", domain)
+}
+
+// findDnsRecords returns the records of the domain with the given name, type and view, and the given
+// value unless it is empty
+func findDnsRecords(service *sdns.SdnsService, domainID int, name, recordType, view, value string) ([]sdns.DnsRecord, error) {
+	records, err := service.ListAllDnsRecords(sdns.DnsRecordListRequest{
+		DomainID:   domainID,
+		RecordName: name,
+		RecordType: strings.ToUpper(recordType),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DNS records: %w", err)
+	}
+
+	// The name filter of the API may match partially
+	matches := make([]sdns.DnsRecord, 0, 1)
+	for _, record := range records {
+		if !strings.EqualFold(record.Name, name) || !strings.EqualFold(record.Type, recordType) || record.View != view {
+			continue
+		}
+		if value != "" && sdns.NormalizeDnsRecordValue(recordType, record.Value) != sdns.NormalizeDnsRecordValue(recordType, value) {
+			continue
+		}
+		matches = append(matches, record)
+	}
+	return matches, nil
+}
+
+// findDnsRecordToAdopt returns the existing record matching name, type and view, preferring the one
+// with the same value. It returns nil when there is none.
+func findDnsRecordToAdopt(service *sdns.SdnsService, domainID int, name, recordType, view, value string) (*sdns.DnsRecord, error) {
+	records, err := findDnsRecords(service, domainID, name, recordType, view, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(records) <= 1 {
+		if len(records) == 0 {
+			return nil, nil
+		}
+		return &records[0], nil
+	}
+
+	for i := range records {
+		if sdns.NormalizeDnsRecordValue(recordType, records[i].Value) == sdns.NormalizeDnsRecordValue(recordType, value) {
+			return &records[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%d DNS records named %s %s %s exist and none has value %s, import the one to adopt instead", len(records), name, recordType, view, value)
+}
@@ -1,13 +1,17 @@
 package resource
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func ResourceEdgenextDnsRecord() *schema.Resource {
@@ -17,7 +21,14 @@ func ResourceEdgenextDnsRecord() *schema.Resource {
 		Update: resourceDnsRecordUpdate,
 		Delete: resourceDnsRecordDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceDnsRecordImport,
+		},
+
+		CustomizeDiff: resourceDnsRecordCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -43,15 +54,84 @@ func ResourceEdgenextDnsRecord() *schema.Resource {
 				Description: "The view/line for the record",
 			},
 			"value": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The value of the record",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ConflictsWith:    []string{"srv", "caa"},
+				DiffSuppressFunc: suppressEquivalentDnsRecordValue,
+				Description:      "The value of the record. Host names, TXT quoting and IPv6 addresses are normalized. Required unless srv or caa is set",
+			},
+			"srv": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"value", "caa"},
+				Description:   "The fields of an SRV record, an alternative to value",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"priority": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 65535),
+							Description:  "The priority of the target",
+						},
+						"weight": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 65535),
+							Description:  "The relative weight of targets with the same priority",
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 65535),
+							Description:  "The port of the service",
+						},
+						"target": {
+							Type:             schema.TypeString,
+							Required:         true,
+							DiffSuppressFunc: suppressEquivalentDnsHostName,
+							Description:      "The host name of the target",
+						},
+					},
+				},
+			},
+			"caa": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"value", "srv"},
+				Description:   "The fields of a CAA record, an alternative to value",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"flags": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntBetween(0, 255),
+							Description:  "The flags, 128 marks the property as critical",
+						},
+						"tag": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(sdns.CaaTags, false),
+							Description:  "The property tag: issue, issuewild or iodef",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The property value, e.g. letsencrypt.org",
+						},
+					},
+				},
 			},
 			"mx": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Default:     0,
-				Description: "MX priority",
+				Description: "MX priority, required for MX records",
 			},
 			"ttl": {
 				Type:        schema.TypeInt,
@@ -65,25 +145,169 @@ func ResourceEdgenextDnsRecord() *schema.Resource {
 				Description: "Remark for the record",
 			},
 			"status": {
-				Type:        schema.TypeInt,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      sdns.DnsRecordStatusEnabled,
+				ValidateFunc: validation.IntInSlice([]int{sdns.DnsRecordStatusEnabled, sdns.DnsRecordStatusPaused}),
+				Description:  "Status of the record (1 for enabled, 2 for paused)",
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
 				Optional:    true,
-				Default:     1,
-				Description: "Status of the record (1 for enabled, 2 for paused)",
+				Default:     false,
+				Description: "Adopt an existing record with the same name, type and view instead of creating one. When several exist, the one with the same value is adopted",
 			},
 		},
 	}
 }
 
+// resourceDnsRecordCustomizeDiff builds the value of SRV and CAA records from their fields, validates
+// the value for the record type and checks type and view against the record types and lines of the domain
+func resourceDnsRecordCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if err := composeDnsRecordValue(d); err != nil {
+		return err
+	}
+
+	raw := d.GetRawConfig()
+	for _, key := range []string{"domain_id", "name", "type", "view", "mx"} {
+		if !raw.GetAttr(key).IsKnown() {
+			return nil
+		}
+	}
+	if d.Id() != "" && !d.HasChanges("domain_id", "name", "type", "view", "value", "mx") {
+		return nil
+	}
+
+	if d.NewValueKnown("value") {
+		var mx *int
+		if !raw.GetAttr("mx").IsNull() {
+			priority := d.Get("mx").(int)
+			mx = &priority
+		}
+		if err := sdns.ValidateDnsRecordValue(d.Get("name").(string), d.Get("type").(string), d.Get("value").(string), mx); err != nil {
+			return err
+		}
+	}
+
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+	return service.ValidateDnsRecordTypeAndView(d.Get("domain_id").(int), d.Get("type").(string), d.Get("view").(string))
+}
+
+// composeDnsRecordValue sets value from the srv or caa block when one is configured
+func composeDnsRecordValue(d *schema.ResourceDiff) error {
+	raw := d.GetRawConfig()
+	recordType := raw.GetAttr("type")
+	if !recordType.IsKnown() || recordType.IsNull() {
+		return nil
+	}
+
+	for _, block := range []string{"srv", "caa"} {
+		config := raw.GetAttr(block)
+		if config.IsKnown() && (config.IsNull() || config.LengthInt() == 0) {
+			continue
+		}
+		if !strings.EqualFold(recordType.AsString(), block) {
+			return fmt.Errorf("%s is only valid for %s records", block, strings.ToUpper(block))
+		}
+		if !config.IsWhollyKnown() {
+			return d.SetNewComputed("value")
+		}
+
+		fields := d.Get(block).([]interface{})[0].(map[string]interface{})
+		var value string
+		if block == "srv" {
+			value = sdns.FormatSrvValue(fields["priority"].(int), fields["weight"].(int), fields["port"].(int), fields["target"].(string))
+		} else {
+			value = sdns.FormatCaaValue(fields["flags"].(int), fields["tag"].(string), fields["value"].(string))
+		}
+		if sdns.NormalizeDnsRecordValue(recordType.AsString(), d.Get("value").(string)) == value {
+			return nil
+		}
+		return d.SetNew("value", value)
+	}
+
+	if raw.GetAttr("value").IsNull() {
+		return fmt.Errorf("value is required unless srv or caa is set")
+	}
+	return nil
+}
+
+// suppressEquivalentDnsRecordValue ignores differences that disappear once the value is normalized
+func suppressEquivalentDnsRecordValue(_, old, new string, d *schema.ResourceData) bool {
+	if new == "" {
+		return false
+	}
+	recordType := d.Get("type").(string)
+	return sdns.NormalizeDnsRecordValue(recordType, old) == sdns.NormalizeDnsRecordValue(recordType, new)
+}
+
+// suppressEquivalentDnsHostName ignores case and trailing dot differences of host names
+func suppressEquivalentDnsHostName(_, old, new string, _ *schema.ResourceData) bool {
+	return sdns.NormalizeDnsRecordValue("CNAME", old) == sdns.NormalizeDnsRecordValue("CNAME", new)
+}
+
+// flattenDnsRecordFields returns the srv and caa blocks matching value, empty for other record types
+func flattenDnsRecordFields(recordType, value string) (srv, caa []map[string]interface{}) {
+	srv, caa = []map[string]interface{}{}, []map[string]interface{}{}
+	switch strings.ToUpper(recordType) {
+	case "SRV":
+		if priority, weight, port, target, ok := sdns.ParseSrvValue(value); ok {
+			srv = append(srv, map[string]interface{}{
+				"priority": priority,
+				"weight":   weight,
+				"port":     port,
+				"target":   target,
+			})
+		}
+	case "CAA":
+		if flags, tag, caaValue, ok := sdns.ParseCaaValue(value); ok {
+			caa = append(caa, map[string]interface{}{
+				"flags": flags,
+				"tag":   tag,
+				"value": caaValue,
+			})
+		}
+	}
+	return srv, caa
+}
+
 func resourceDnsRecordCreate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*connectivity.EdgeNextClient)
 	service := sdns.NewSdnsService(client)
 
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	domainID := d.Get("domain_id").(int)
+	recordType := d.Get("type").(string)
+	// The value is sent as configured, it is only normalized to compare it with existing records
+	value := d.Get("value").(string)
+
+	if d.Get("adopt_existing").(bool) {
+		existing, err := findDnsRecordToAdopt(service, domainID, d.Get("name").(string), recordType, d.Get("view").(string), value)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			log.Printf("[INFO] Adopting existing DNS record: %d in domain %d", existing.ID, domainID)
+			d.SetId(strconv.Itoa(existing.ID))
+			if err := editDnsRecord(d, service); err != nil {
+				return err
+			}
+			if err := applyDnsRecordStatus(ctx, d, service, existing.Status); err != nil {
+				return err
+			}
+			return resourceDnsRecordRead(d, m)
+		}
+	}
+
 	req := sdns.DnsRecordAddRequest{
-		DomainID:     d.Get("domain_id").(int),
+		DomainID:     domainID,
 		RecordName:   d.Get("name").(string),
-		RecordType:   d.Get("type").(string),
+		RecordType:   recordType,
 		RecordView:   d.Get("view").(string),
-		RecordValue:  d.Get("value").(string),
+		RecordValue:  value,
 		RecordMX:     d.Get("mx").(int),
 		RecordTTL:    d.Get("ttl").(int),
 		RecordRemark: d.Get("remark").(string),
@@ -96,6 +320,10 @@ func resourceDnsRecordCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.SetId(strconv.Itoa(id))
+	// New records are enabled
+	if err := applyDnsRecordStatus(ctx, d, service, sdns.DnsRecordStatusEnabled); err != nil {
+		return err
+	}
 	return resourceDnsRecordRead(d, m)
 }
 
@@ -110,19 +338,15 @@ func resourceDnsRecordRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	log.Printf("[DEBUG] Reading DNS record: %d in domain %d", recordID, domainID)
-	listReq := sdns.DnsRecordListRequest{
-		DomainID: domainID,
-		PerPage:  1000,
-	}
-	resp, err := service.ListDnsRecords(listReq)
+	records, err := service.ListAllDnsRecords(sdns.DnsRecordListRequest{DomainID: domainID})
 	if err != nil {
 		return fmt.Errorf("failed to list DNS records: %w", err)
 	}
 
 	var foundRecord *sdns.DnsRecord
-	for _, r := range resp.List {
-		if r.ID == recordID {
-			foundRecord = &r
+	for i := range records {
+		if records[i].ID == recordID {
+			foundRecord = &records[i]
 			break
 		}
 	}
@@ -137,6 +361,9 @@ func resourceDnsRecordRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("type", foundRecord.Type)
 	d.Set("view", foundRecord.View)
 	d.Set("value", foundRecord.Value)
+	srv, caa := flattenDnsRecordFields(foundRecord.Type, foundRecord.Value)
+	d.Set("srv", srv)
+	d.Set("caa", caa)
 	d.Set("mx", foundRecord.MX)
 	d.Set("ttl", foundRecord.TTL)
 	d.Set("status", foundRecord.Status)
@@ -149,6 +376,27 @@ func resourceDnsRecordUpdate(d *schema.ResourceData, m interface{}) error {
 	client := m.(*connectivity.EdgeNextClient)
 	service := sdns.NewSdnsService(client)
 
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	if d.HasChanges("name", "type", "view", "value", "mx", "ttl", "remark") {
+		if err := editDnsRecord(d, service); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("status") {
+		oldStatus, _ := d.GetChange("status")
+		if err := applyDnsRecordStatus(ctx, d, service, oldStatus.(int)); err != nil {
+			return err
+		}
+	}
+
+	return resourceDnsRecordRead(d, m)
+}
+
+// editDnsRecord sends the configured record
+func editDnsRecord(d *schema.ResourceData, service *sdns.SdnsService) error {
 	recordID, err := strconv.Atoi(d.Id())
 	if err != nil {
 		return fmt.Errorf("invalid record ID: %s", d.Id())
@@ -168,12 +416,35 @@ func resourceDnsRecordUpdate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	log.Printf("[INFO] Updating DNS record: %d in domain %d", recordID, domainID)
-	err = service.UpdateDnsRecord(req)
-	if err != nil {
+	if err := service.UpdateDnsRecord(req); err != nil {
 		return fmt.Errorf("failed to update DNS record: %w", err)
 	}
+	return nil
+}
 
-	return resourceDnsRecordRead(d, m)
+// applyDnsRecordStatus pauses or enables the record when current differs from the configured status
+func applyDnsRecordStatus(ctx context.Context, d *schema.ResourceData, service *sdns.SdnsService, current int) error {
+	status := d.Get("status").(int)
+	if status == current {
+		return nil
+	}
+
+	recordID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid record ID: %s", d.Id())
+	}
+	domainID := d.Get("domain_id").(int)
+
+	if status == sdns.DnsRecordStatusPaused {
+		log.Printf("[INFO] Pausing DNS record: %d in domain %d", recordID, domainID)
+		return runDnsBatchTask(ctx, service, domainID, "pause", func() (int, error) {
+			return service.BatchPauseDnsRecords(domainID, []int{recordID})
+		}, dnsRecordsInStatus([]int{recordID}, sdns.DnsRecordStatusPaused))
+	}
+	log.Printf("[INFO] Enabling DNS record: %d in domain %d", recordID, domainID)
+	return runDnsBatchTask(ctx, service, domainID, "enable", func() (int, error) {
+		return service.BatchEnableDnsRecords(domainID, []int{recordID})
+	}, dnsRecordsInStatus([]int{recordID}, sdns.DnsRecordStatusEnabled))
 }
 
 func resourceDnsRecordDelete(d *schema.ResourceData, m interface{}) error {
@@ -195,3 +466,51 @@ func resourceDnsRecordDelete(d *schema.ResourceData, m interface{}) error {
 	d.SetId("")
 	return nil
 }
+
+// resourceDnsRecordImport accepts <domain>/<record_id> or <domain>/<name>/<type>/<view>[/<value>],
+// where domain is the domain name or ID
+func resourceDnsRecordImport(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	parts := strings.SplitN(d.Id(), "/", 5)
+	if len(parts) != 2 && len(parts) != 4 && len(parts) != 5 {
+		return nil, fmt.Errorf("invalid import ID %q, expected <domain>/<record_id> or <domain>/<name>/<type>/<view>[/<value>]", d.Id())
+	}
+
+	domainID, err := resolveDnsDomainID(service, parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	recordID := 0
+	if len(parts) == 2 {
+		recordID, err = strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid record ID %q in import ID %q", parts[1], d.Id())
+		}
+	} else {
+		value := ""
+		if len(parts) == 5 {
+			value = parts[4]
+		}
+		records, err := findDnsRecords(service, domainID, parts[1], parts[2], parts[3], value)
+		if err != nil {
+			return nil, err
+		}
+		switch len(records) {
+		case 0:
+			return nil, fmt.Errorf("no DNS record matches %q", d.Id())
+		case 1:
+			recordID = records[0].ID
+		default:
+			return nil, fmt.Errorf("%d DNS records match %q, add the value or use <domain>/<record_id>", len(records), d.Id())
+		}
+	}
+
+	d.SetId(strconv.Itoa(recordID))
+	if err := d.Set("domain_id", domainID); err != nil {
+		return nil, fmt.Errorf("error setting domain_id: %w", err)
+	}
+	return []*schema.ResourceData{d}, nil
+}
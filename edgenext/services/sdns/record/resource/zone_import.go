@@ -0,0 +1,219 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceEdgenextDnsZoneImport returns the resource importing a zone file into a domain
+func ResourceEdgenextDnsZoneImport() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDnsZoneImportCreate,
+		Read:   resourceDnsZoneImportRead,
+		Delete: resourceDnsZoneImportDelete,
+
+		CustomizeDiff: resourceDnsZoneImportCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the domain to import the records into",
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The RFC 1035 zone file, e.g. file(\"example.com.zone\")",
+			},
+			"default_ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      600,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "TTL of the records without one when the zone file has no $TTL",
+			},
+			"default_view": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     sdns.DnsRecordViewDefault,
+				Description: "View of the records without a view= comment",
+			},
+			// Computed fields
+			"record_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of records imported, updated after every batch so a failed import shows its progress",
+			},
+			"skipped_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of records of the zone file that already existed and were not imported again",
+			},
+		},
+	}
+}
+
+// resourceDnsZoneImportCustomizeDiff parses the zone file and validates the record types at plan time
+func resourceDnsZoneImportCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	raw := d.GetRawConfig()
+	if !raw.GetAttr("content").IsKnown() || !raw.GetAttr("domain_id").IsKnown() {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChanges("content", "domain_id", "default_ttl", "default_view") {
+		return nil
+	}
+
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	_, err := parseZoneImport(service, d.Get("domain_id").(int), d.Get("content").(string), d.Get("default_ttl").(int), d.Get("default_view").(string))
+	return err
+}
+
+func resourceDnsZoneImportCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	domainID := d.Get("domain_id").(int)
+	records, err := parseZoneImport(service, domainID, d.Get("content").(string), d.Get("default_ttl").(int), d.Get("default_view").(string))
+	if err != nil {
+		return err
+	}
+
+	// Records that already exist are skipped, so that an import retried after a failure only adds
+	// the records still missing
+	existing, err := service.ListAllDnsRecords(sdns.DnsRecordListRequest{DomainID: domainID})
+	if err != nil {
+		return fmt.Errorf("failed to list DNS records: %w", err)
+	}
+	pending := skipExistingZoneImportRecords(records, existing)
+
+	// The ID is set up front so that the progress of a failed import is kept in state
+	d.SetId(fmt.Sprintf("%d-%d", domainID, time.Now().Unix()))
+	if err := d.Set("skipped_count", len(records)-len(pending)); err != nil {
+		log.Printf("[WARN] Failed to set skipped_count: %v", err)
+	}
+	if err := d.Set("record_count", 0); err != nil {
+		log.Printf("[WARN] Failed to set record_count: %v", err)
+	}
+
+	log.Printf("[INFO] Importing %d DNS record(s) into domain %d, %d already exist", len(pending), domainID, len(records)-len(pending))
+	for start := 0; start < len(pending); start += dnsRecordBatchSize {
+		end := start + dnsRecordBatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		req := sdns.DnsRecordImportRequest{DomainID: domainID}
+		for _, record := range pending[start:end] {
+			req.Records = append(req.Records, sdns.DnsRecordBatchItem{
+				RecordName:  record.Name,
+				RecordType:  record.Type,
+				RecordView:  record.View,
+				RecordValue: record.Value,
+				RecordMX:    record.MX,
+				RecordTTL:   record.TTL,
+			})
+		}
+		if err := runDnsBatchTask(ctx, service, domainID, "import", func() (int, error) {
+			return service.ImportDnsRecords(req)
+		}, dnsRecordsAdded(req.Records)); err != nil {
+			return fmt.Errorf("imported %d of %d DNS record(s): %w", start, len(pending), err)
+		}
+		if err := d.Set("record_count", end); err != nil {
+			log.Printf("[WARN] Failed to set record_count: %v", err)
+		}
+	}
+
+	log.Printf("[INFO] DNS zone import completed successfully: %s", d.Id())
+	return resourceDnsZoneImportRead(d, m)
+}
+
+func resourceDnsZoneImportRead(d *schema.ResourceData, m interface{}) error {
+	// The import is a one-time operation, the records are not tracked afterwards
+	log.Printf("[DEBUG] Reading DNS zone import: %s", d.Id())
+	return nil
+}
+
+func resourceDnsZoneImportDelete(d *schema.ResourceData, m interface{}) error {
+	// Imported records are left in place, only remove from state
+	log.Printf("[INFO] Deleting DNS zone import from state: %s", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// parseZoneImport parses the zone file of the domain, validates the record values and checks the
+// record types and views against those of the domain
+func parseZoneImport(service *sdns.SdnsService, domainID int, content string, defaultTTL int, defaultView string) ([]sdns.ZoneFileRecord, error) {
+	domain, err := service.GetDnsDomainInfo(domainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS domain info: %w", err)
+	}
+
+	records, err := sdns.ParseZoneFile(content, domain.Domain, defaultTTL, defaultView)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("zone file has no records to import")
+	}
+
+	// Load the catalogue up front so that a failing lookup is reported once
+	if _, err := service.CachedDnsRecordTypes(); err != nil {
+		return nil, fmt.Errorf("failed to list DNS record types: %w", err)
+	}
+	if _, err := service.CachedDnsRecordLines(domainID); err != nil {
+		return nil, fmt.Errorf("failed to list DNS record lines: %w", err)
+	}
+
+	var errs []string
+	for _, record := range records {
+		mx := record.MX
+		if err := sdns.ValidateDnsRecordValue(record.Name, record.Type, record.Value, &mx); err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: %v", record.Line, err))
+		} else if err := service.ValidateDnsRecordTypeAndView(domainID, record.Type, record.View); err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: %v", record.Line, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid zone file:\n  %s", strings.Join(errs, "\n  "))
+	}
+	return records, nil
+}
+
+// skipExistingZoneImportRecords returns the records of the zone file that do not exist in the domain yet
+func skipExistingZoneImportRecords(records []sdns.ZoneFileRecord, existing []sdns.DnsRecord) []sdns.ZoneFileRecord {
+	counts := make(map[string]int, len(existing))
+	for _, record := range existing {
+		counts[zoneRecordKey(record.Name, record.Type, record.View, record.Value)]++
+	}
+
+	pending := make([]sdns.ZoneFileRecord, 0, len(records))
+	for _, record := range records {
+		key := zoneRecordKey(record.Name, record.Type, record.View, record.Value)
+		if counts[key] > 0 {
+			counts[key]--
+			continue
+		}
+		pending = append(pending, record)
+	}
+	return pending
+}
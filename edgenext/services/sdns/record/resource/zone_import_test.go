@@ -0,0 +1,25 @@
+package resource
+
+import (
+	"testing"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
+)
+
+func TestSkipExistingZoneImportRecords(t *testing.T) {
+	existing := []sdns.DnsRecord{
+		{ID: 1, Name: "www", Type: "A", View: "any", Value: "1.1.1.1"},
+		{ID: 2, Name: "cdn", Type: "CNAME", View: "any", Value: "cdn.example.net."},
+	}
+	records := []sdns.ZoneFileRecord{
+		{Line: 1, Name: "www", Type: "A", View: "any", Value: "1.1.1.1"},
+		{Line: 2, Name: "www", Type: "A", View: "any", Value: "2.2.2.2"},
+		{Line: 3, Name: "cdn", Type: "CNAME", View: "any", Value: "CDN.example.net"},
+		{Line: 4, Name: "www", Type: "A", View: "telecom", Value: "1.1.1.1"},
+	}
+
+	pending := skipExistingZoneImportRecords(records, existing)
+	if len(pending) != 2 || pending[0].Line != 2 || pending[1].Line != 4 {
+		t.Errorf("expected lines 2 and 4 to be imported, got %+v", pending)
+	}
+}
@@ -0,0 +1,557 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ResourceEdgenextDnsZoneRecords returns the resource owning every record of a domain, or every
+// record whose name matches name_filter
+func ResourceEdgenextDnsZoneRecords() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDnsZoneRecordsCreate,
+		Read:   resourceDnsZoneRecordsRead,
+		Update: resourceDnsZoneRecordsUpdate,
+		Delete: resourceDnsZoneRecordsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDnsZoneRecordsImport,
+		},
+
+		CustomizeDiff: resourceDnsZoneRecordsCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the domain",
+			},
+			"name_filter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Glob pattern (e.g. www, *.api) of the record names managed by this resource. By default every record of the domain is managed",
+			},
+			"record": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The records of the domain. Records matching name_filter that are not listed here are deleted",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the record (e.g., www)",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The type of the record (A, CNAME, etc.)",
+						},
+						"view": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The view/line for the record",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The value of the record",
+						},
+						"mx": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     0,
+							Description: "MX priority",
+						},
+						"ttl": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     600,
+							Description: "TTL in seconds",
+						},
+						"remark": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Remark for the record",
+						},
+						"status": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      sdns.DnsRecordStatusEnabled,
+							ValidateFunc: validation.IntInSlice([]int{sdns.DnsRecordStatusEnabled, sdns.DnsRecordStatusPaused}),
+							Description:  "Status of the record (1 for enabled, 2 for paused)",
+						},
+					},
+				},
+			},
+			// Computed fields
+			"record_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The record IDs, keyed by name/type/view/value with the value normalized",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// zoneRecord is a record as configured in the record set
+type zoneRecord struct {
+	Name   string
+	Type   string
+	View   string
+	Value  string
+	MX     int
+	TTL    int
+	Remark string
+	Status int
+}
+
+// key identifies a record, records with the same key are updated in place
+func (r zoneRecord) key() string {
+	return zoneRecordKey(r.Name, r.Type, r.View, r.Value)
+}
+
+func zoneRecordKey(name, recordType, view, value string) string {
+	value = sdns.NormalizeDnsRecordValue(recordType, value)
+	return strings.Join([]string{strings.ToLower(name), strings.ToUpper(recordType), view, value}, "/")
+}
+
+// zoneRecordsPlan lists the calls needed to turn the current records into the configured ones.
+// Conflict holds the deletions that must happen before the additions, Delete the ones that run last.
+type zoneRecordsPlan struct {
+	Add      []zoneRecord
+	Edit     map[int]zoneRecord
+	Conflict []int
+	Delete   []int
+	Pause    []int
+	Enable   []int
+}
+
+// planZoneRecords diffs the configured records against the managed records of the domain
+func planZoneRecords(desired []zoneRecord, current []sdns.DnsRecord) zoneRecordsPlan {
+	sorted := make([]sdns.DnsRecord, len(current))
+	copy(sorted, current)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	byKey := make(map[string][]sdns.DnsRecord)
+	for _, record := range sorted {
+		key := zoneRecordKey(record.Name, record.Type, record.View, record.Value)
+		byKey[key] = append(byKey[key], record)
+	}
+
+	plan := zoneRecordsPlan{Edit: make(map[int]zoneRecord)}
+	matched := make(map[int]bool)
+	for _, want := range desired {
+		matches := byKey[want.key()]
+		if len(matches) == 0 {
+			plan.Add = append(plan.Add, want)
+			continue
+		}
+		have := matches[0]
+		byKey[want.key()] = matches[1:]
+		matched[have.ID] = true
+
+		if have.MX != want.MX || have.TTL != want.TTL || have.Remark != want.Remark {
+			plan.Edit[have.ID] = want
+		}
+		if have.Status != want.Status {
+			if want.Status == sdns.DnsRecordStatusPaused {
+				plan.Pause = append(plan.Pause, have.ID)
+			} else {
+				plan.Enable = append(plan.Enable, have.ID)
+			}
+		}
+	}
+
+	// Unlisted records and duplicates of listed ones are deleted, after the additions unless they
+	// would conflict with one
+	for _, record := range sorted {
+		if matched[record.ID] {
+			continue
+		}
+		if conflictsWithZoneRecords(record, plan.Add) {
+			plan.Conflict = append(plan.Conflict, record.ID)
+		} else {
+			plan.Delete = append(plan.Delete, record.ID)
+		}
+	}
+	return plan
+}
+
+// conflictsWithZoneRecords reports whether record cannot coexist with one of the records to add. A
+// CNAME cannot share its name and view with any other record, including another CNAME.
+func conflictsWithZoneRecords(record sdns.DnsRecord, add []zoneRecord) bool {
+	for _, want := range add {
+		if !strings.EqualFold(record.Name, want.Name) || record.View != want.View {
+			continue
+		}
+		if strings.EqualFold(record.Type, "CNAME") || strings.EqualFold(want.Type, "CNAME") {
+			return true
+		}
+	}
+	return false
+}
+
+// matchZoneRecordName reports whether name matches the name_filter glob, an empty filter matches all
+func matchZoneRecordName(filter, name string) bool {
+	if filter == "" {
+		return true
+	}
+	matched, err := path.Match(strings.ToLower(filter), strings.ToLower(name))
+	return err == nil && matched
+}
+
+// listManagedZoneRecords lists every record of the domain matching the filter. Locked records,
+// such as the default NS records, are never managed.
+func listManagedZoneRecords(service *sdns.SdnsService, domainID int, filter string) ([]sdns.DnsRecord, error) {
+	records, err := service.ListAllDnsRecords(sdns.DnsRecordListRequest{DomainID: domainID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DNS records: %w", err)
+	}
+
+	managed := make([]sdns.DnsRecord, 0, len(records))
+	for _, record := range records {
+		if !record.Locked && matchZoneRecordName(filter, record.Name) {
+			managed = append(managed, record)
+		}
+	}
+	return managed, nil
+}
+
+func expandZoneRecords(set *schema.Set) []zoneRecord {
+	records := make([]zoneRecord, 0, set.Len())
+	for _, item := range set.List() {
+		m := item.(map[string]interface{})
+		records = append(records, zoneRecord{
+			Name:   m["name"].(string),
+			Type:   m["type"].(string),
+			View:   m["view"].(string),
+			Value:  m["value"].(string),
+			MX:     m["mx"].(int),
+			TTL:    m["ttl"].(int),
+			Remark: m["remark"].(string),
+			Status: m["status"].(int),
+		})
+	}
+	return records
+}
+
+// parseZoneRecordsID splits an ID of the form <domain_id> or <domain_id>/<name_filter>
+func parseZoneRecordsID(id string) (int, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	domainID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid zone records ID %q, expected <domain_id> or <domain_id>/<name_filter>", id)
+	}
+	if len(parts) == 1 {
+		return domainID, "", nil
+	}
+	return domainID, parts[1], nil
+}
+
+// resourceDnsZoneRecordsCustomizeDiff checks that the records match name_filter, are not configured
+// twice and use record types and lines of the domain
+func resourceDnsZoneRecordsCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	filter := d.Get("name_filter").(string)
+	if _, err := path.Match(filter, ""); err != nil {
+		return fmt.Errorf("invalid name_filter %q: %w", filter, err)
+	}
+
+	seen := make(map[string]bool)
+	for _, record := range expandZoneRecords(d.Get("record").(*schema.Set)) {
+		// Names and values computed from other resources are checked once known
+		if record.Name == "" || record.Value == "" {
+			continue
+		}
+		if !matchZoneRecordName(filter, record.Name) {
+			return fmt.Errorf("record %s does not match name_filter %q", record.Name, filter)
+		}
+		if seen[record.key()] {
+			return fmt.Errorf("record %s %s %s %s is configured more than once", record.Name, record.Type, record.View, record.Value)
+		}
+		seen[record.key()] = true
+		if record.Type != "" {
+			mx := record.MX
+			if err := sdns.ValidateDnsRecordValue(record.Name, record.Type, record.Value, &mx); err != nil {
+				return fmt.Errorf("record %s: %w", record.Name, err)
+			}
+		}
+	}
+
+	if !d.GetRawConfig().GetAttr("domain_id").IsKnown() || !d.HasChanges("domain_id", "record") {
+		return nil
+	}
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+	for _, record := range expandZoneRecords(d.Get("record").(*schema.Set)) {
+		if record.Type == "" || record.View == "" {
+			continue
+		}
+		if err := service.ValidateDnsRecordTypeAndView(d.Get("domain_id").(int), record.Type, record.View); err != nil {
+			return fmt.Errorf("record %s: %w", record.Name, err)
+		}
+	}
+	return nil
+}
+
+func resourceDnsZoneRecordsCreate(d *schema.ResourceData, m interface{}) error {
+	domainID := d.Get("domain_id").(int)
+	id := strconv.Itoa(domainID)
+	if filter := d.Get("name_filter").(string); filter != "" {
+		id = fmt.Sprintf("%d/%s", domainID, filter)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	log.Printf("[INFO] Creating DNS zone records: %s", id)
+	if err := applyZoneRecords(ctx, d, m); err != nil {
+		return err
+	}
+
+	d.SetId(id)
+	return resourceDnsZoneRecordsRead(d, m)
+}
+
+func resourceDnsZoneRecordsRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	domainID, filter, err := parseZoneRecordsID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Reading DNS zone records: %s", d.Id())
+	records, err := listManagedZoneRecords(service, domainID, filter)
+	if err != nil {
+		return err
+	}
+
+	// Keep the configured spelling of records that only differ once normalized
+	configured := make(map[string]zoneRecord)
+	for _, record := range expandZoneRecords(d.Get("record").(*schema.Set)) {
+		configured[record.key()] = record
+	}
+
+	items := make([]map[string]interface{}, 0, len(records))
+	ids := make(map[string]string, len(records))
+	for _, record := range records {
+		if want, ok := configured[zoneRecordKey(record.Name, record.Type, record.View, record.Value)]; ok {
+			record.Name, record.Type, record.Value = want.Name, want.Type, want.Value
+		}
+		items = append(items, map[string]interface{}{
+			"name":   record.Name,
+			"type":   record.Type,
+			"view":   record.View,
+			"value":  record.Value,
+			"mx":     record.MX,
+			"ttl":    record.TTL,
+			"remark": record.Remark,
+			"status": record.Status,
+		})
+		ids[zoneRecordKey(record.Name, record.Type, record.View, record.Value)] = strconv.Itoa(record.ID)
+	}
+
+	d.Set("domain_id", domainID)
+	d.Set("name_filter", filter)
+	if err := d.Set("record", items); err != nil {
+		return fmt.Errorf("failed to set record: %w", err)
+	}
+	if err := d.Set("record_ids", ids); err != nil {
+		return fmt.Errorf("failed to set record_ids: %w", err)
+	}
+
+	return nil
+}
+
+func resourceDnsZoneRecordsUpdate(d *schema.ResourceData, m interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+
+	if d.HasChange("record") {
+		log.Printf("[INFO] Updating DNS zone records: %s", d.Id())
+		if err := applyZoneRecords(ctx, d, m); err != nil {
+			return err
+		}
+	}
+
+	return resourceDnsZoneRecordsRead(d, m)
+}
+
+func resourceDnsZoneRecordsDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+
+	domainID := d.Get("domain_id").(int)
+	records, err := listManagedZoneRecords(service, domainID, d.Get("name_filter").(string))
+	if err != nil {
+		return err
+	}
+
+	// Only delete the records known to this resource that still exist
+	known := make(map[string]bool)
+	for _, id := range d.Get("record_ids").(map[string]interface{}) {
+		known[id.(string)] = true
+	}
+	recordIDs := make([]int, 0, len(known))
+	for _, record := range records {
+		if known[strconv.Itoa(record.ID)] {
+			recordIDs = append(recordIDs, record.ID)
+		}
+	}
+
+	log.Printf("[INFO] Deleting %d DNS zone record(s): %s", len(recordIDs), d.Id())
+	if err := deleteZoneRecords(ctx, service, domainID, recordIDs); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceDnsZoneRecordsImport accepts <domain_id> or <domain_id>/<name_filter>
+func resourceDnsZoneRecordsImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	if _, _, err := parseZoneRecordsID(d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// applyZoneRecords diffs the configured record set against a full listing of the domain and applies
+// the difference through the batch endpoints
+func applyZoneRecords(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	domainID := d.Get("domain_id").(int)
+	filter := d.Get("name_filter").(string)
+	desired := expandZoneRecords(d.Get("record").(*schema.Set))
+
+	current, err := listManagedZoneRecords(service, domainID, filter)
+	if err != nil {
+		return err
+	}
+	plan := planZoneRecords(desired, current)
+	log.Printf("[INFO] DNS zone records plan for domain %d: %d to add, %d to edit, %d to delete, %d to pause, %d to enable",
+		domainID, len(plan.Add), len(plan.Edit), len(plan.Conflict)+len(plan.Delete), len(plan.Pause), len(plan.Enable))
+
+	// Only records that conflict with an addition, e.g. a CNAME turned into an A record, are deleted
+	// up front, everything else is deleted once the new records are in place
+	if err := deleteZoneRecords(ctx, service, domainID, plan.Conflict); err != nil {
+		return err
+	}
+
+	// There is no batch edit endpoint
+	editIDs := make([]int, 0, len(plan.Edit))
+	for recordID := range plan.Edit {
+		editIDs = append(editIDs, recordID)
+	}
+	sort.Ints(editIDs)
+	for _, recordID := range editIDs {
+		record := plan.Edit[recordID]
+		if err := service.UpdateDnsRecord(sdns.DnsRecordEditRequest{
+			RecordID:     recordID,
+			DomainID:     domainID,
+			RecordName:   record.Name,
+			RecordType:   record.Type,
+			RecordView:   record.View,
+			RecordValue:  record.Value,
+			RecordMX:     record.MX,
+			RecordTTL:    record.TTL,
+			RecordRemark: record.Remark,
+		}); err != nil {
+			return fmt.Errorf("failed to update DNS record %d: %w", recordID, err)
+		}
+	}
+
+	for start := 0; start < len(plan.Add); start += dnsRecordBatchSize {
+		end := start + dnsRecordBatchSize
+		if end > len(plan.Add) {
+			end = len(plan.Add)
+		}
+		req := sdns.DnsRecordBatchAddRequest{DomainID: domainID}
+		for _, record := range plan.Add[start:end] {
+			req.Records = append(req.Records, sdns.DnsRecordBatchItem{
+				RecordName:   record.Name,
+				RecordType:   record.Type,
+				RecordView:   record.View,
+				RecordValue:  record.Value,
+				RecordMX:     record.MX,
+				RecordTTL:    record.TTL,
+				RecordRemark: record.Remark,
+			})
+		}
+		if err := runDnsBatchTask(ctx, service, domainID, "add", func() (int, error) {
+			return service.BatchAddDnsRecords(req)
+		}, dnsRecordsAdded(req.Records)); err != nil {
+			return err
+		}
+	}
+
+	// Added records are enabled, paused ones only get an ID once the add task has finished
+	for _, record := range plan.Add {
+		if record.Status == sdns.DnsRecordStatusPaused {
+			current, err := listManagedZoneRecords(service, domainID, filter)
+			if err != nil {
+				return err
+			}
+			statusPlan := planZoneRecords(desired, current)
+			plan.Pause, plan.Enable = statusPlan.Pause, statusPlan.Enable
+			break
+		}
+	}
+
+	for _, chunk := range chunkInts(plan.Pause, dnsRecordBatchSize) {
+		if err := runDnsBatchTask(ctx, service, domainID, "pause", func() (int, error) {
+			return service.BatchPauseDnsRecords(domainID, chunk)
+		}, dnsRecordsInStatus(chunk, sdns.DnsRecordStatusPaused)); err != nil {
+			return err
+		}
+	}
+	for _, chunk := range chunkInts(plan.Enable, dnsRecordBatchSize) {
+		if err := runDnsBatchTask(ctx, service, domainID, "enable", func() (int, error) {
+			return service.BatchEnableDnsRecords(domainID, chunk)
+		}, dnsRecordsInStatus(chunk, sdns.DnsRecordStatusEnabled)); err != nil {
+			return err
+		}
+	}
+
+	return deleteZoneRecords(ctx, service, domainID, plan.Delete)
+}
+
+// deleteZoneRecords deletes the records through the batch delete endpoint
+func deleteZoneRecords(ctx context.Context, service *sdns.SdnsService, domainID int, recordIDs []int) error {
+	for _, chunk := range chunkInts(recordIDs, dnsRecordBatchSize) {
+		if err := runDnsBatchTask(ctx, service, domainID, "delete", func() (int, error) {
+			return service.BatchDeleteDnsRecords(domainID, chunk)
+		}, dnsRecordsDeleted(chunk)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package resource
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
+)
+
+func TestPlanZoneRecords(t *testing.T) {
+	current := []sdns.DnsRecord{
+		{ID: 3, Name: "www", Type: "A", View: "any", Value: "1.1.1.1", TTL: 600, Status: 1},
+		{ID: 1, Name: "WWW", Type: "a", View: "any", Value: "1.1.1.1", TTL: 600, Status: 1},
+		{ID: 2, Name: "api", Type: "CNAME", View: "any", Value: "api.example.net", TTL: 600, Status: 1},
+		{ID: 4, Name: "mail", Type: "MX", View: "any", Value: "mx.example.com", MX: 10, TTL: 600, Status: 1},
+		{ID: 5, Name: "old", Type: "A", View: "any", Value: "2.2.2.2", TTL: 600, Status: 2},
+	}
+	desired := []zoneRecord{
+		{Name: "www", Type: "A", View: "any", Value: "1.1.1.1", TTL: 600, Status: 1},
+		{Name: "api", Type: "CNAME", View: "any", Value: "api.example.net", TTL: 300, Status: 2},
+		{Name: "mail", Type: "MX", View: "any", Value: "mx.example.com", MX: 10, TTL: 600, Status: 1},
+		{Name: "new", Type: "A", View: "any", Value: "3.3.3.3", TTL: 600, Status: 1},
+	}
+
+	plan := planZoneRecords(desired, current)
+
+	if len(plan.Add) != 1 || plan.Add[0].Name != "new" {
+		t.Errorf("expected to add new, got %+v", plan.Add)
+	}
+	if len(plan.Edit) != 1 || plan.Edit[2].TTL != 300 {
+		t.Errorf("expected to edit the TTL of record 2, got %+v", plan.Edit)
+	}
+	// The oldest duplicate is kept, the other one is deleted along with the unlisted record
+	if !reflect.DeepEqual(plan.Delete, []int{3, 5}) {
+		t.Errorf("expected to delete [3 5], got %v", plan.Delete)
+	}
+	if !reflect.DeepEqual(plan.Pause, []int{2}) {
+		t.Errorf("expected to pause [2], got %v", plan.Pause)
+	}
+	if len(plan.Enable) != 0 {
+		t.Errorf("expected nothing to enable, got %v", plan.Enable)
+	}
+}
+
+func TestPlanZoneRecordsConflicts(t *testing.T) {
+	current := []sdns.DnsRecord{
+		{ID: 1, Name: "www", Type: "CNAME", View: "any", Value: "old.example.net", TTL: 600, Status: 1},
+		{ID: 2, Name: "api", Type: "A", View: "any", Value: "1.1.1.1", TTL: 600, Status: 1},
+		{ID: 3, Name: "cdn", Type: "CNAME", View: "any", Value: "a.example.net", TTL: 600, Status: 1},
+		{ID: 4, Name: "cdn", Type: "CNAME", View: "telecom", Value: "b.example.net", TTL: 600, Status: 1},
+	}
+	desired := []zoneRecord{
+		// A CNAME replaced by an A record
+		{Name: "www", Type: "A", View: "any", Value: "2.2.2.2", TTL: 600, Status: 1},
+		// An A record replaced by another address
+		{Name: "api", Type: "A", View: "any", Value: "3.3.3.3", TTL: 600, Status: 1},
+		// A CNAME pointed elsewhere in one view only
+		{Name: "cdn", Type: "CNAME", View: "any", Value: "c.example.net", TTL: 600, Status: 1},
+	}
+
+	plan := planZoneRecords(desired, current)
+	if !reflect.DeepEqual(plan.Conflict, []int{1, 3}) {
+		t.Errorf("expected to delete [1 3] before adding, got %v", plan.Conflict)
+	}
+	if !reflect.DeepEqual(plan.Delete, []int{2, 4}) {
+		t.Errorf("expected to delete [2 4] last, got %v", plan.Delete)
+	}
+}
+
+func TestDnsBatchApplied(t *testing.T) {
+	records := []sdns.DnsRecord{
+		{ID: 1, Name: "www", Type: "A", View: "any", Value: "1.1.1.1", Status: 1},
+		{ID: 2, Name: "api", Type: "CNAME", View: "any", Value: "api.example.net.", Status: 2},
+	}
+
+	if dnsRecordsDeleted([]int{2, 3})(records) {
+		t.Errorf("expected record 2 to block the delete")
+	}
+	if !dnsRecordsDeleted([]int{3})(records) {
+		t.Errorf("expected the delete of a missing record to be applied")
+	}
+	if !dnsRecordsInStatus([]int{2}, sdns.DnsRecordStatusPaused)(records) {
+		t.Errorf("expected record 2 to be paused")
+	}
+	if dnsRecordsInStatus([]int{1, 2}, sdns.DnsRecordStatusPaused)(records) {
+		t.Errorf("expected record 1 to block the pause")
+	}
+	if !dnsRecordsAdded([]sdns.DnsRecordBatchItem{{RecordName: "API", RecordType: "cname", RecordView: "any", RecordValue: "api.example.net"}})(records) {
+		t.Errorf("expected the normalized CNAME to be found")
+	}
+	if dnsRecordsAdded([]sdns.DnsRecordBatchItem{{RecordName: "new", RecordType: "A", RecordView: "any", RecordValue: "2.2.2.2"}})(records) {
+		t.Errorf("expected the missing record to block the add")
+	}
+}
+
+func TestPlanZoneRecordsNormalizedValues(t *testing.T) {
+	current := []sdns.DnsRecord{
+		{ID: 1, Name: "www", Type: "CNAME", View: "any", Value: "CDN.example.net.", TTL: 600, Status: 1},
+		{ID: 2, Name: "@", Type: "TXT", View: "any", Value: `"v=spf1 -all"`, TTL: 600, Status: 1},
+	}
+	desired := []zoneRecord{
+		{Name: "www", Type: "cname", View: "any", Value: "cdn.example.net", TTL: 600, Status: 1},
+		{Name: "@", Type: "TXT", View: "any", Value: "v=spf1 -all", TTL: 600, Status: 1},
+	}
+
+	plan := planZoneRecords(desired, current)
+	if len(plan.Add) != 0 || len(plan.Delete) != 0 || len(plan.Edit) != 0 {
+		t.Errorf("expected equivalent values to match, got %+v", plan)
+	}
+}
+
+func TestMatchZoneRecordName(t *testing.T) {
+	tests := []struct {
+		filter string
+		name   string
+		want   bool
+	}{
+		{"", "www", true},
+		{"www", "WWW", true},
+		{"*.api", "v1.api", true},
+		{"*.api", "api", false},
+		{"mail*", "mail2", true},
+		{"[", "www", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchZoneRecordName(tt.filter, tt.name); got != tt.want {
+			t.Errorf("matchZoneRecordName(%q, %q) = %v, want %v", tt.filter, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseZoneRecordsID(t *testing.T) {
+	domainID, filter, err := parseZoneRecordsID("123/*.api")
+	if err != nil || domainID != 123 || filter != "*.api" {
+		t.Errorf("unexpected result: %d %q %v", domainID, filter, err)
+	}
+	domainID, filter, err = parseZoneRecordsID("123")
+	if err != nil || domainID != 123 || filter != "" {
+		t.Errorf("unexpected result: %d %q %v", domainID, filter, err)
+	}
+	if _, _, err := parseZoneRecordsID("example.com"); err == nil {
+		t.Errorf("expected an error for a non-numeric domain ID")
+	}
+}
@@ -0,0 +1,22 @@
+package record_group
+
+import (
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns/record_group/data"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns/record_group/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Resources returns all record group-related resources
+func Resources() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"edgenext_sdns_record_group":          resource.ResourceEdgenextDnsRecordGroup(),
+		"edgenext_sdns_record_group_relation": resource.ResourceEdgenextDnsRecordGroupRelation(),
+	}
+}
+
+// DataSources returns all record group-related data sources
+func DataSources() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"edgenext_sdns_record_groups": data.DataSourceEdgenextDnsRecordGroup(),
+	}
+}
@@ -0,0 +1,224 @@
+package resource
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dnsRecordGroupRelationBatchSize is the number of records sent in one relation call
+const dnsRecordGroupRelationBatchSize = 100
+
+func ResourceEdgenextDnsRecordGroupRelation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDnsRecordGroupRelationCreate,
+		Read:   resourceDnsRecordGroupRelationRead,
+		Update: resourceDnsRecordGroupRelationUpdate,
+		Delete: resourceDnsRecordGroupRelationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the domain the group belongs to",
+			},
+			"group_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the record group",
+			},
+			"record_ids": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "IDs of the records in the group. Records added to the group outside of Terraform are removed",
+			},
+		},
+	}
+}
+
+func resourceDnsRecordGroupRelationCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	domainID := d.Get("domain_id").(int)
+	groupID := d.Get("group_id").(int)
+
+	// Take over the group: records already in it and not configured are removed
+	current, err := listDnsRecordGroupMembers(service, domainID, groupID)
+	if err != nil {
+		return err
+	}
+	desired := expandIntSet(d.Get("record_ids").(*schema.Set))
+
+	log.Printf("[INFO] Assigning %d DNS record(s) to record group %d in domain %d", len(desired), groupID, domainID)
+	if err := syncDnsRecordGroupMembers(service, domainID, groupID, current, desired); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%d/%d", domainID, groupID))
+
+	return resourceDnsRecordGroupRelationRead(d, m)
+}
+
+func resourceDnsRecordGroupRelationRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	domainID, groupID, err := parseDnsRecordGroupID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Reading DNS record group relation: %d in domain %d", groupID, domainID)
+	if _, err := service.GetDnsRecordGroupInfo(domainID, groupID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("failed to get DNS record group info: %w", err)
+	}
+
+	recordIDs, err := listDnsRecordGroupMembers(service, domainID, groupID)
+	if err != nil {
+		return err
+	}
+
+	d.Set("domain_id", domainID)
+	d.Set("group_id", groupID)
+	d.Set("record_ids", recordIDs)
+
+	return nil
+}
+
+func resourceDnsRecordGroupRelationUpdate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	domainID, groupID, err := parseDnsRecordGroupID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("record_ids") {
+		oldIDs, newIDs := d.GetChange("record_ids")
+		log.Printf("[INFO] Updating DNS record group relation: %d in domain %d", groupID, domainID)
+		if err := syncDnsRecordGroupMembers(service, domainID, groupID, expandIntSet(oldIDs.(*schema.Set)), expandIntSet(newIDs.(*schema.Set))); err != nil {
+			return err
+		}
+	}
+
+	return resourceDnsRecordGroupRelationRead(d, m)
+}
+
+func resourceDnsRecordGroupRelationDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	domainID, groupID, err := parseDnsRecordGroupID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Removing all records from DNS record group: %d in domain %d", groupID, domainID)
+	recordIDs := expandIntSet(d.Get("record_ids").(*schema.Set))
+	if err := syncDnsRecordGroupMembers(service, domainID, groupID, recordIDs, nil); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// listDnsRecordGroupMembers returns the sorted IDs of the records in the group
+func listDnsRecordGroupMembers(service *sdns.SdnsService, domainID, groupID int) ([]int, error) {
+	records, err := service.ListAllDnsRecords(sdns.DnsRecordListRequest{DomainID: domainID, GroupID: groupID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list DNS records of group %d: %w", groupID, err)
+	}
+
+	ids := make([]int, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, record.ID)
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// syncDnsRecordGroupMembers adds the records in desired but not in current to the group and removes
+// those in current but not in desired
+func syncDnsRecordGroupMembers(service *sdns.SdnsService, domainID, groupID int, current, desired []int) error {
+	add, remove := diffInts(current, desired)
+
+	for _, change := range []struct {
+		action string
+		ids    []int
+	}{
+		{sdns.DnsRecordGroupRelationDelete, remove},
+		{sdns.DnsRecordGroupRelationAdd, add},
+	} {
+		for start := 0; start < len(change.ids); start += dnsRecordGroupRelationBatchSize {
+			end := start + dnsRecordGroupRelationBatchSize
+			if end > len(change.ids) {
+				end = len(change.ids)
+			}
+			err := service.BindRecordsToGroup(sdns.DnsRecordGroupRelationRequest{
+				DomainID:  domainID,
+				GroupID:   groupID,
+				RecordIDs: change.ids[start:end],
+				Action:    change.action,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to %s DNS records of group %d: %w", change.action, groupID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// diffInts returns the elements of desired missing from current, and those of current missing from
+// desired, both sorted
+func diffInts(current, desired []int) (add, remove []int) {
+	inCurrent := make(map[int]bool, len(current))
+	for _, id := range current {
+		inCurrent[id] = true
+	}
+	inDesired := make(map[int]bool, len(desired))
+	for _, id := range desired {
+		inDesired[id] = true
+		if !inCurrent[id] {
+			add = append(add, id)
+		}
+	}
+	for _, id := range current {
+		if !inDesired[id] {
+			remove = append(remove, id)
+		}
+	}
+	sort.Ints(add)
+	sort.Ints(remove)
+	return add, remove
+}
+
+func expandIntSet(set *schema.Set) []int {
+	list := set.List()
+	vs := make([]int, 0, len(list))
+	for _, v := range list {
+		vs = append(vs, v.(int))
+	}
+	return vs
+}
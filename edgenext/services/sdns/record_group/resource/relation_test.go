@@ -0,0 +1,33 @@
+package resource
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffInts(t *testing.T) {
+	add, remove := diffInts([]int{3, 1, 2}, []int{2, 5, 4, 1})
+	if !reflect.DeepEqual(add, []int{4, 5}) {
+		t.Errorf("add = %v, want [4 5]", add)
+	}
+	if !reflect.DeepEqual(remove, []int{3}) {
+		t.Errorf("remove = %v, want [3]", remove)
+	}
+
+	add, remove = diffInts([]int{1, 2}, nil)
+	if add != nil || !reflect.DeepEqual(remove, []int{1, 2}) {
+		t.Errorf("diffInts to empty = %v, %v, want [], [1 2]", add, remove)
+	}
+}
+
+func TestParseDnsRecordGroupID(t *testing.T) {
+	domainID, groupID, err := parseDnsRecordGroupID("12/34")
+	if err != nil || domainID != 12 || groupID != 34 {
+		t.Errorf("parseDnsRecordGroupID(12/34) = %d, %d, %v", domainID, groupID, err)
+	}
+	for _, id := range []string{"34", "12/x", "12/34/56", ""} {
+		if _, _, err := parseDnsRecordGroupID(id); err == nil {
+			t.Errorf("parseDnsRecordGroupID(%q) succeeded, want error", id)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package resource
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/sdns"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceEdgenextDnsRecordGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDnsRecordGroupCreate,
+		Read:   resourceDnsRecordGroupRead,
+		Delete: resourceDnsRecordGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		// The API has no endpoint to edit a record group, every change replaces it
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the domain the group belongs to",
+			},
+			"group_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the record group",
+			},
+			"remark": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Remark for the record group",
+			},
+			// Computed fields
+			"group_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the record group",
+			},
+		},
+	}
+}
+
+func resourceDnsRecordGroupCreate(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	req := sdns.DnsRecordGroupAddRequest{
+		DomainID:  d.Get("domain_id").(int),
+		GroupName: d.Get("group_name").(string),
+		Remark:    d.Get("remark").(string),
+	}
+
+	log.Printf("[INFO] Creating DNS record group: %s in domain %d", req.GroupName, req.DomainID)
+	resp, err := service.AddDnsRecordGroup(req)
+	if err != nil {
+		return fmt.Errorf("failed to create DNS record group: %w", err)
+	}
+
+	d.SetId(fmt.Sprintf("%d/%d", req.DomainID, resp.Data.ID))
+
+	return resourceDnsRecordGroupRead(d, m)
+}
+
+func resourceDnsRecordGroupRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	domainID, groupID, err := parseDnsRecordGroupID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Reading DNS record group: %d in domain %d", groupID, domainID)
+	info, err := service.GetDnsRecordGroupInfo(domainID, groupID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("failed to get DNS record group info: %w", err)
+	}
+
+	d.Set("domain_id", domainID)
+	d.Set("group_id", info.ID)
+	d.Set("group_name", info.GroupName)
+	d.Set("remark", info.Remark)
+
+	return nil
+}
+
+func resourceDnsRecordGroupDelete(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+	service := sdns.NewSdnsService(client)
+
+	domainID, groupID, err := parseDnsRecordGroupID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting DNS record group: %d in domain %d", groupID, domainID)
+	err = service.DeleteDnsRecordGroup(domainID, groupID)
+	if err != nil {
+		return fmt.Errorf("failed to delete DNS record group: %w", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// parseDnsRecordGroupID splits an ID of the form <domain_id>/<group_id>
+func parseDnsRecordGroupID(id string) (int, int, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) == 2 {
+		domainID, err := strconv.Atoi(parts[0])
+		if err == nil {
+			groupID, err := strconv.Atoi(parts[1])
+			if err == nil {
+				return domainID, groupID, nil
+			}
+		}
+	}
+	return 0, 0, fmt.Errorf("invalid record group ID %q, expected <domain_id>/<group_id>", id)
+}
@@ -0,0 +1,202 @@
+package sdns
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// txtChunkSize is the maximum length of a TXT character string
+const txtChunkSize = 255
+
+// CaaTags are the property tags accepted in CAA records
+var CaaTags = []string{"issue", "issuewild", "iodef"}
+
+var dnsHostNamePattern = regexp.MustCompile(`^(\*\.)?([a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9_])?\.)*[a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9_])?$`)
+
+// NormalizeDnsRecordValue returns the form of value the service stores, so that equivalent values
+// compare equal: host names are lowercased without the trailing dot, TXT values are quoted and
+// split into 255 byte strings, and addresses are in canonical form.
+func NormalizeDnsRecordValue(recordType, value string) string {
+	value = strings.TrimSpace(value)
+	switch strings.ToUpper(recordType) {
+	case "A", "AAAA":
+		if ip := net.ParseIP(value); ip != nil {
+			return ip.String()
+		}
+	case "CNAME", "NS", "PTR", "MX":
+		return normalizeDnsHostName(value)
+	case "TXT", "SPF":
+		return quoteTxtValue(unquoteTxtValue(value))
+	case "SRV":
+		if priority, weight, port, target, ok := ParseSrvValue(value); ok {
+			return FormatSrvValue(priority, weight, port, target)
+		}
+	case "CAA":
+		if flags, tag, caaValue, ok := ParseCaaValue(value); ok {
+			return FormatCaaValue(flags, tag, caaValue)
+		}
+	}
+	return value
+}
+
+// ValidateDnsRecordValue checks value against the record type. mx is nil when no priority was set.
+func ValidateDnsRecordValue(name, recordType, value string, mx *int) error {
+	recordType = strings.ToUpper(recordType)
+	if recordType == "CNAME" && (name == "@" || name == "") {
+		return fmt.Errorf("CNAME records are not allowed at the zone apex (@), use A/AAAA records instead")
+	}
+
+	switch recordType {
+	case "A":
+		if ip := net.ParseIP(value); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("A record value %q is not an IPv4 address", value)
+		}
+	case "AAAA":
+		if ip := net.ParseIP(value); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("AAAA record value %q is not an IPv6 address", value)
+		}
+	case "CNAME", "NS", "PTR":
+		if !isDnsHostName(value) {
+			return fmt.Errorf("%s record value %q is not a host name", recordType, value)
+		}
+	case "MX":
+		if mx == nil {
+			return fmt.Errorf("MX record requires mx (the priority)")
+		}
+		if *mx < 0 || *mx > 65535 {
+			return fmt.Errorf("MX priority %d must be between 0 and 65535", *mx)
+		}
+		if !isDnsHostName(value) {
+			return fmt.Errorf("MX record value %q is not a host name", value)
+		}
+	case "TXT", "SPF":
+		if unquoteTxtValue(value) == "" {
+			return fmt.Errorf("%s record value must not be empty", recordType)
+		}
+	case "SRV":
+		if !strings.HasPrefix(name, "_") || !strings.Contains(name, "._") {
+			return fmt.Errorf("SRV record name %q must have the form _service._proto", name)
+		}
+		if _, _, _, _, ok := ParseSrvValue(value); !ok {
+			return fmt.Errorf("SRV record value %q must have the form \"priority weight port target\"", value)
+		}
+	case "CAA":
+		if _, _, _, ok := ParseCaaValue(value); !ok {
+			return fmt.Errorf("CAA record value %q must have the form 'flags tag \"value\"' with tag one of %s", value, strings.Join(CaaTags, ", "))
+		}
+	}
+	return nil
+}
+
+// FormatSrvValue returns the value of an SRV record
+func FormatSrvValue(priority, weight, port int, target string) string {
+	return fmt.Sprintf("%d %d %d %s", priority, weight, port, normalizeDnsHostName(target))
+}
+
+// ParseSrvValue splits the value of an SRV record, a target of "." means no service
+func ParseSrvValue(value string) (priority, weight, port int, target string, ok bool) {
+	fields := strings.Fields(value)
+	if len(fields) != 4 {
+		return 0, 0, 0, "", false
+	}
+	numbers := make([]int, 3)
+	for i := range numbers {
+		n, err := strconv.Atoi(fields[i])
+		if err != nil || n < 0 || n > 65535 {
+			return 0, 0, 0, "", false
+		}
+		numbers[i] = n
+	}
+	target = fields[3]
+	if target != "." && !isDnsHostName(target) {
+		return 0, 0, 0, "", false
+	}
+	return numbers[0], numbers[1], numbers[2], target, true
+}
+
+// FormatCaaValue returns the value of a CAA record
+func FormatCaaValue(flags int, tag, value string) string {
+	return fmt.Sprintf("%d %s %s", flags, strings.ToLower(tag), strconv.Quote(value))
+}
+
+// ParseCaaValue splits the value of a CAA record, the property value may be quoted
+func ParseCaaValue(value string) (flags int, tag, caaValue string, ok bool) {
+	fields := strings.SplitN(strings.TrimSpace(value), " ", 3)
+	if len(fields) != 3 {
+		return 0, "", "", false
+	}
+	flags, err := strconv.Atoi(fields[0])
+	if err != nil || flags < 0 || flags > 255 {
+		return 0, "", "", false
+	}
+	tag = strings.ToLower(fields[1])
+	if !containsString(CaaTags, tag) {
+		return 0, "", "", false
+	}
+	caaValue = strings.TrimSpace(fields[2])
+	if unquoted, err := strconv.Unquote(caaValue); err == nil {
+		caaValue = unquoted
+	}
+	return flags, tag, caaValue, true
+}
+
+func normalizeDnsHostName(name string) string {
+	if name == "." {
+		return name
+	}
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+func isDnsHostName(name string) bool {
+	name = normalizeDnsHostName(name)
+	return len(name) <= 253 && dnsHostNamePattern.MatchString(name)
+}
+
+// unquoteTxtValue concatenates the quoted character strings of a TXT value, an unquoted value is
+// returned as is
+func unquoteTxtValue(value string) string {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, `"`) {
+		return value
+	}
+
+	var out strings.Builder
+	inQuote := false
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '\\' && inQuote && i+1 < len(value):
+			i++
+			out.WriteByte(value[i])
+		case c == '"':
+			inQuote = !inQuote
+		case inQuote:
+			out.WriteByte(c)
+		}
+	}
+	return out.String()
+}
+
+// quoteTxtValue quotes a TXT value, splitting it into character strings of at most 255 bytes
+func quoteTxtValue(value string) string {
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	var chunks []string
+	for len(value) > txtChunkSize {
+		chunks = append(chunks, `"`+escape.Replace(value[:txtChunkSize])+`"`)
+		value = value[txtChunkSize:]
+	}
+	chunks = append(chunks, `"`+escape.Replace(value)+`"`)
+	return strings.Join(chunks, " ")
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
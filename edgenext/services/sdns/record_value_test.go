@@ -0,0 +1,81 @@
+package sdns
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeDnsRecordValue(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	tests := []struct {
+		recordType string
+		value      string
+		want       string
+	}{
+		{"A", " 192.0.2.1 ", "192.0.2.1"},
+		{"AAAA", "2001:DB8:0:0:0:0:0:1", "2001:db8::1"},
+		{"CNAME", "WWW.Example.com.", "www.example.com"},
+		{"mx", "Mail.Example.com.", "mail.example.com"},
+		{"TXT", "v=spf1 -all", `"v=spf1 -all"`},
+		{"TXT", `"v=spf1 " "-all"`, `"v=spf1 -all"`},
+		{"TXT", `say "hi"`, `"say \"hi\""`},
+		{"TXT", long, `"` + long[:255] + `" "` + long[255:] + `"`},
+		{"SRV", "10  60 5060 SIP.example.com.", "10 60 5060 sip.example.com"},
+		{"CAA", "0 ISSUE letsencrypt.org", `0 issue "letsencrypt.org"`},
+		{"CAA", `128 iodef "mailto:security@example.com"`, `128 iodef "mailto:security@example.com"`},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeDnsRecordValue(tt.recordType, tt.value); got != tt.want {
+			t.Errorf("NormalizeDnsRecordValue(%q, %q) = %q, want %q", tt.recordType, tt.value, got, tt.want)
+		}
+	}
+
+	// Normalizing twice changes nothing
+	for _, tt := range tests {
+		once := NormalizeDnsRecordValue(tt.recordType, tt.value)
+		if twice := NormalizeDnsRecordValue(tt.recordType, once); twice != once {
+			t.Errorf("NormalizeDnsRecordValue is not idempotent for %q: %q != %q", tt.value, twice, once)
+		}
+	}
+}
+
+func TestValidateDnsRecordValue(t *testing.T) {
+	priority := 10
+	tests := []struct {
+		name       string
+		recordName string
+		recordType string
+		value      string
+		mx         *int
+		wantErr    string
+	}{
+		{"valid A", "www", "A", "192.0.2.1", nil, ""},
+		{"IPv6 in A", "www", "A", "2001:db8::1", nil, "not an IPv4 address"},
+		{"IPv4 in AAAA", "www", "AAAA", "192.0.2.1", nil, "not an IPv6 address"},
+		{"CNAME at apex", "@", "CNAME", "example.net", nil, "not allowed at the zone apex"},
+		{"CNAME to IP", "www", "CNAME", "192.0.2.1:80", nil, "not a host name"},
+		{"valid CNAME", "www", "CNAME", "cdn.example.net.", nil, ""},
+		{"MX without priority", "@", "MX", "mail.example.com", nil, "requires mx"},
+		{"valid MX", "@", "MX", "mail.example.com", &priority, ""},
+		{"empty TXT", "@", "TXT", `""`, nil, "must not be empty"},
+		{"SRV bad name", "sip", "SRV", "10 60 5060 sip.example.com", nil, "_service._proto"},
+		{"SRV bad value", "_sip._tcp", "SRV", "10 60 sip.example.com", nil, "priority weight port target"},
+		{"valid SRV", "_sip._tcp", "SRV", "10 60 5060 sip.example.com", nil, ""},
+		{"CAA bad tag", "@", "CAA", `0 issuer "letsencrypt.org"`, nil, "flags tag"},
+		{"valid CAA", "@", "CAA", `0 issue "letsencrypt.org"`, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDnsRecordValue(tt.recordName, tt.recordType, tt.value, tt.mx)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -1,5 +1,9 @@
 package sdns
 
+import (
+	"encoding/json"
+)
+
 // Status represents the standard API status response
 type Status struct {
 	Code    int    `json:"code"`
@@ -50,6 +54,26 @@ type DnsDomainAddData struct {
 	ID int `json:"id"`
 }
 
+type DnsDomainBatchAddRequest struct {
+	Domains []string `json:"domains"`
+}
+
+type DnsDomainBatchAddResponse struct {
+	Status Status                `json:"status"`
+	Data   DnsDomainBatchAddData `json:"data"`
+}
+
+type DnsDomainBatchAddData struct {
+	Success []DnsDomainBatchAddResult `json:"success"`
+	Fail    []DnsDomainBatchAddResult `json:"fail"`
+}
+
+type DnsDomainBatchAddResult struct {
+	ID      int    `json:"id"`
+	Domain  string `json:"domain"`
+	Message string `json:"msg"`
+}
+
 type DnsDomainDeleteRequest struct {
 	DomainIDs []int `json:"domain_ids"`
 }
@@ -58,6 +82,49 @@ type DnsDomainDeleteResponse struct {
 	Status Status `json:"status"`
 }
 
+type DnsDomainServersRequest struct {
+	DomainID int `json:"domain_id"`
+}
+
+type DnsDomainServersResponse struct {
+	Status Status           `json:"status"`
+	Data   DnsDomainServers `json:"data"`
+}
+
+// DnsDomainServers are the authoritative name servers assigned to a domain, and those found in the
+// registry when the service last checked the delegation
+type DnsDomainServers struct {
+	Servers        []string `json:"servers"`
+	CurrentServers []string `json:"current_servers"`
+}
+
+// UnmarshalJSON accepts both a bare list of assigned servers and an object
+func (s *DnsDomainServers) UnmarshalJSON(data []byte) error {
+	var servers []string
+	if err := json.Unmarshal(data, &servers); err == nil {
+		s.Servers = servers
+		return nil
+	}
+
+	type plain DnsDomainServers
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+type DnsDomainStatRequest struct {
+	DomainID int `json:"domain_id"`
+}
+
+type DnsDomainStatResponse struct {
+	Status Status        `json:"status"`
+	Data   DnsDomainStat `json:"data"`
+}
+
+type DnsDomainStat struct {
+	RecordCount       int `json:"record_count"`
+	EnableRecordCount int `json:"enable_record_count"`
+	PauseRecordCount  int `json:"pause_record_count"`
+}
+
 // ============================================================================
 // DNS Domain Group Types
 // ============================================================================
@@ -135,6 +202,12 @@ type DnsGroupDomain struct {
 	Domain   string      `json:"domain"`
 }
 
+// Values of DnsGroupDomainSaveRequest.Action
+const (
+	DnsGroupRelationAdd    = "add"
+	DnsGroupRelationDelete = "del"
+)
+
 type DnsGroupDomainSaveRequest struct {
 	GroupID   int    `json:"group_id"`
 	DomainIDs []int  `json:"domain_ids,omitempty"`
@@ -216,3 +289,205 @@ type DnsRecordDeleteRequest struct {
 	RecordID int `json:"record_id"`
 	DomainID int `json:"domain_id"`
 }
+
+// Values of DnsRecord.Status
+const (
+	DnsRecordStatusEnabled = 1
+	DnsRecordStatusPaused  = 2
+)
+
+type DnsRecordBatchItem struct {
+	RecordName   string `json:"record_name"`
+	RecordType   string `json:"record_type"`
+	RecordView   string `json:"record_view"`
+	RecordValue  string `json:"record_value"`
+	RecordMX     int    `json:"record_mx,omitempty"`
+	RecordTTL    int    `json:"record_ttl,omitempty"`
+	RecordRemark string `json:"record_remark,omitempty"`
+}
+
+type DnsRecordBatchAddRequest struct {
+	DomainID int                  `json:"domain_id"`
+	Records  []DnsRecordBatchItem `json:"records"`
+}
+
+// DnsRecordBatchRequest is shared by batch delete, pause and enable
+type DnsRecordBatchRequest struct {
+	DomainID  int   `json:"domain_id"`
+	RecordIDs []int `json:"record_ids"`
+}
+
+type DnsRecordBatchResponse struct {
+	Status Status `json:"status"`
+	Data   struct {
+		TaskID int `json:"task_id"`
+	} `json:"data"`
+}
+
+type DnsRecordImportRequest struct {
+	DomainID int                  `json:"domain_id"`
+	Records  []DnsRecordBatchItem `json:"records"`
+}
+
+type DnsRecordTypesResponse struct {
+	Status Status              `json:"status"`
+	Data   []DnsRecordTypeInfo `json:"data"`
+}
+
+type DnsRecordTypeInfo struct {
+	Type        string `json:"type"`
+	Description string `json:"desc"`
+}
+
+// UnmarshalJSON accepts both a bare type name and an object
+func (t *DnsRecordTypeInfo) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		t.Type = name
+		return nil
+	}
+
+	type plain DnsRecordTypeInfo
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+type DnsRecordLinesRequest struct {
+	DomainID int `json:"domain_id,omitempty"`
+}
+
+type DnsRecordLinesResponse struct {
+	Status Status          `json:"status"`
+	Data   []DnsRecordLine `json:"data"`
+}
+
+// DnsRecordLine is a resolution line, the value of a record view. Geo lines are nested under their
+// region or ISP.
+type DnsRecordLine struct {
+	Code     string          `json:"code"`
+	Name     string          `json:"name"`
+	Parent   string          `json:"parent,omitempty"`
+	Children []DnsRecordLine `json:"children,omitempty"`
+}
+
+// ============================================================================
+// DNS Record Group Types
+// ============================================================================
+
+type DnsRecordGroupListRequest struct {
+	DomainID  int    `json:"domain_id"`
+	Page      int    `json:"page,omitempty"`
+	PerPage   int    `json:"per_page,omitempty"`
+	GroupName string `json:"group_name,omitempty"`
+}
+
+type DnsRecordGroupListResponse struct {
+	Status Status                 `json:"status"`
+	Data   DnsRecordGroupListData `json:"data"`
+}
+
+type DnsRecordGroupListData struct {
+	Total int              `json:"total"`
+	List  []DnsRecordGroup `json:"list"`
+}
+
+type DnsRecordGroup struct {
+	ID        int    `json:"id"`
+	DomainID  int    `json:"domain_id"`
+	GroupName string `json:"group_name"`
+	Remark    string `json:"remark"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type DnsRecordGroupAddRequest struct {
+	DomainID  int    `json:"domain_id"`
+	GroupName string `json:"group_name"`
+	Remark    string `json:"remark,omitempty"`
+}
+
+type DnsRecordGroupAddResponse struct {
+	Status Status `json:"status"`
+	Data   struct {
+		ID int `json:"id"`
+	} `json:"data"`
+}
+
+type DnsRecordGroupDelRequest struct {
+	DomainID int `json:"domain_id"`
+	GroupID  int `json:"group_id"`
+}
+
+// Values of DnsRecordGroupRelationRequest.Action
+const (
+	DnsRecordGroupRelationAdd    = "add"
+	DnsRecordGroupRelationDelete = "del"
+)
+
+type DnsRecordGroupRelationRequest struct {
+	DomainID  int    `json:"domain_id"`
+	GroupID   int    `json:"group_id"`
+	RecordIDs []int  `json:"record_ids"`
+	Action    string `json:"action"`
+}
+
+// ============================================================================
+// DNS Batch Task Types
+// ============================================================================
+
+// Values of DnsBatchTask.Status and DnsBatchTaskItem.Status
+const (
+	DnsBatchTaskStatusWaiting  = 0
+	DnsBatchTaskStatusRunning  = 1
+	DnsBatchTaskStatusFinished = 2
+	DnsBatchTaskStatusFailed   = 3
+)
+
+type DnsBatchTaskListRequest struct {
+	DomainID int `json:"domain_id,omitempty"`
+	Page     int `json:"page,omitempty"`
+	PerPage  int `json:"per_page,omitempty"`
+}
+
+type DnsBatchTaskListResponse struct {
+	Status Status               `json:"status"`
+	Data   DnsBatchTaskListData `json:"data"`
+}
+
+type DnsBatchTaskListData struct {
+	Total int            `json:"total"`
+	List  []DnsBatchTask `json:"list"`
+}
+
+type DnsBatchTask struct {
+	ID           int    `json:"id"`
+	DomainID     int    `json:"domain_id"`
+	TaskType     string `json:"task_type"`
+	Status       int    `json:"status"`
+	Total        int    `json:"total"`
+	SuccessCount int    `json:"success_count"`
+	FailCount    int    `json:"fail_count"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+type DnsBatchTaskDetailRequest struct {
+	TaskID int `json:"task_id"`
+}
+
+type DnsBatchTaskDetailResponse struct {
+	Status Status             `json:"status"`
+	Data   DnsBatchTaskDetail `json:"data"`
+}
+
+type DnsBatchTaskDetail struct {
+	DnsBatchTask
+	List []DnsBatchTaskItem `json:"list"`
+}
+
+type DnsBatchTaskItem struct {
+	RecordName  string `json:"record_name"`
+	RecordType  string `json:"record_type"`
+	RecordValue string `json:"record_value"`
+	Status      int    `json:"status"`
+	Message     string `json:"msg"`
+}
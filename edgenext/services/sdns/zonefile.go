@@ -0,0 +1,374 @@
+package sdns
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DnsRecordViewDefault is the view answering every query that no other view matches
+const DnsRecordViewDefault = "any"
+
+// ZoneFileRecord is a record parsed from a zone file, with names relative to the zone
+type ZoneFileRecord struct {
+	Line  int
+	Name  string
+	Type  string
+	View  string
+	Value string
+	MX    int
+	TTL   int
+}
+
+// zoneFileViewComment sets the view of a record from a trailing comment, as written by FormatZoneFile
+var zoneFileViewComment = regexp.MustCompile(`\bview=(\S+)`)
+
+// zoneFileLine is a logical line of a zone file, parentheses joining several physical lines
+type zoneFileLine struct {
+	number   int
+	indented bool
+	tokens   []string
+	comment  string
+}
+
+// ParseZoneFile parses an RFC 1035 zone file for zone. $ORIGIN and $TTL are supported and relative
+// names are expanded. SOA records and the NS records of the apex are skipped since they belong to the
+// DNS service. Records without a TTL get the $TTL value, or defaultTTL when the file has none.
+func ParseZoneFile(content, zone string, defaultTTL int, defaultView string) ([]ZoneFileRecord, error) {
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	origin := zone + "."
+	ttl := defaultTTL
+	owner := ""
+
+	lines, err := splitZoneFile(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []ZoneFileRecord
+	var errs []string
+	for _, line := range lines {
+		tokens := line.tokens
+		if strings.HasPrefix(tokens[0], "$") {
+			switch strings.ToUpper(tokens[0]) {
+			case "$ORIGIN":
+				if len(tokens) < 2 {
+					errs = append(errs, fmt.Sprintf("line %d: $ORIGIN requires a domain name", line.number))
+					continue
+				}
+				origin = zoneFileAbsoluteName(tokens[1], origin)
+			case "$TTL":
+				if len(tokens) < 2 {
+					errs = append(errs, fmt.Sprintf("line %d: $TTL requires a value", line.number))
+					continue
+				}
+				value, ok := parseZoneFileTTL(tokens[1])
+				if !ok {
+					errs = append(errs, fmt.Sprintf("line %d: invalid $TTL %q", line.number, tokens[1]))
+					continue
+				}
+				ttl = value
+			default:
+				errs = append(errs, fmt.Sprintf("line %d: %s is not supported", line.number, tokens[0]))
+			}
+			continue
+		}
+
+		if !line.indented {
+			owner = zoneFileAbsoluteName(tokens[0], origin)
+			tokens = tokens[1:]
+		}
+		if owner == "" {
+			errs = append(errs, fmt.Sprintf("line %d: record without an owner name", line.number))
+			continue
+		}
+
+		record := ZoneFileRecord{Line: line.number, TTL: ttl, View: defaultView}
+		// The TTL and class may come in either order before the type
+		for len(tokens) > 0 {
+			if value, ok := parseZoneFileTTL(tokens[0]); ok {
+				record.TTL = value
+			} else if !isZoneFileClass(tokens[0]) {
+				break
+			}
+			tokens = tokens[1:]
+		}
+		if len(tokens) < 2 {
+			errs = append(errs, fmt.Sprintf("line %d: record without type or data", line.number))
+			continue
+		}
+		record.Type = strings.ToUpper(tokens[0])
+		rdata := tokens[1:]
+
+		name, ok := zoneFileRelativeName(owner, zone)
+		if !ok {
+			errs = append(errs, fmt.Sprintf("line %d: %s is outside of zone %s", line.number, strings.TrimSuffix(owner, "."), zone))
+			continue
+		}
+		record.Name = name
+		if match := zoneFileViewComment.FindStringSubmatch(line.comment); match != nil {
+			record.View = match[1]
+		}
+
+		if record.Type == "SOA" || (record.Type == "NS" && name == "@") {
+			continue
+		}
+		if err := setZoneFileRecordValue(&record, rdata, origin); err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: %v", line.number, err))
+			continue
+		}
+		records = append(records, record)
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid zone file:\n  %s", strings.Join(errs, "\n  "))
+	}
+	return records, nil
+}
+
+// setZoneFileRecordValue fills the value, and the priority of MX records, from the record data
+func setZoneFileRecordValue(record *ZoneFileRecord, rdata []string, origin string) error {
+	switch record.Type {
+	case "A", "AAAA":
+		if len(rdata) != 1 {
+			return fmt.Errorf("%s record requires one address", record.Type)
+		}
+		record.Value = rdata[0]
+	case "CNAME", "NS", "PTR":
+		if len(rdata) != 1 {
+			return fmt.Errorf("%s record requires one host name", record.Type)
+		}
+		record.Value = zoneFileHostName(rdata[0], origin)
+	case "MX":
+		if len(rdata) != 2 {
+			return fmt.Errorf("MX record requires a priority and a host name")
+		}
+		priority, err := strconv.Atoi(rdata[0])
+		if err != nil {
+			return fmt.Errorf("invalid MX priority %q", rdata[0])
+		}
+		record.MX = priority
+		record.Value = zoneFileHostName(rdata[1], origin)
+	case "TXT", "SPF":
+		record.Value = strings.Join(rdata, " ")
+	case "SRV":
+		if len(rdata) != 4 {
+			return fmt.Errorf("SRV record requires priority, weight, port and target")
+		}
+		record.Value = strings.Join(append(rdata[:3:3], zoneFileHostName(rdata[3], origin)), " ")
+	default:
+		record.Value = strings.Join(rdata, " ")
+	}
+	record.Value = NormalizeDnsRecordValue(record.Type, record.Value)
+	return nil
+}
+
+// splitZoneFile splits content into logical lines, dropping comments and blank lines
+func splitZoneFile(content string) ([]zoneFileLine, error) {
+	var lines []zoneFileLine
+	current := zoneFileLine{number: 1}
+	var token strings.Builder
+	inToken, inQuote, inComment := false, false, false
+	depth, number := 0, 1
+	startOfLine := true
+
+	flushToken := func() {
+		if inToken {
+			current.tokens = append(current.tokens, token.String())
+			token.Reset()
+			inToken = false
+		}
+	}
+
+	runes := []rune(content)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if inComment && r != '\n' {
+			current.comment += string(r)
+			continue
+		}
+
+		switch {
+		case r == '\n':
+			inComment = false
+			if inQuote {
+				return nil, fmt.Errorf("invalid zone file:\n  line %d: unterminated quoted string", number)
+			}
+			flushToken()
+			number++
+			if depth == 0 {
+				if len(current.tokens) > 0 {
+					lines = append(lines, current)
+				}
+				current = zoneFileLine{number: number}
+				startOfLine = true
+				continue
+			}
+		case inQuote:
+			if r == '\\' && i+1 < len(runes) {
+				token.WriteRune(r)
+				i++
+				r = runes[i]
+			} else if r == '"' {
+				inQuote = false
+			}
+			token.WriteRune(r)
+		case r == '"':
+			inQuote, inToken = true, true
+			token.WriteRune(r)
+		case r == ';':
+			flushToken()
+			inComment = true
+		case r == '(':
+			flushToken()
+			depth++
+		case r == ')':
+			flushToken()
+			if depth == 0 {
+				return nil, fmt.Errorf("invalid zone file:\n  line %d: unbalanced parenthesis", number)
+			}
+			depth--
+		case r == ' ' || r == '\t' || r == '\r':
+			if startOfLine && depth == 0 && len(current.tokens) == 0 && r != '\r' {
+				current.indented = true
+			}
+			flushToken()
+		default:
+			inToken = true
+			token.WriteRune(r)
+		}
+		startOfLine = false
+	}
+
+	if inQuote || depth > 0 {
+		return nil, fmt.Errorf("invalid zone file:\n  line %d: unterminated quoted string or parenthesis", number)
+	}
+	flushToken()
+	if len(current.tokens) > 0 {
+		lines = append(lines, current)
+	}
+	return lines, nil
+}
+
+// parseZoneFileTTL parses a TTL in seconds or with BIND units, e.g. 3600 or 1h30m
+func parseZoneFileTTL(value string) (int, bool) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return seconds, seconds >= 0
+	}
+
+	units := map[byte]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	total, number, digits := 0, 0, 0
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c >= '0' && c <= '9' {
+			number = number*10 + int(c-'0')
+			digits++
+			continue
+		}
+		unit, ok := units[c|0x20]
+		if !ok || digits == 0 {
+			return 0, false
+		}
+		total += number * unit
+		number, digits = 0, 0
+	}
+	if digits > 0 {
+		return 0, false
+	}
+	return total, true
+}
+
+func isZoneFileClass(token string) bool {
+	switch strings.ToUpper(token) {
+	case "IN", "CH", "HS", "CS":
+		return true
+	}
+	return false
+}
+
+// zoneFileAbsoluteName expands @ and relative names against origin, the result ends with a dot
+func zoneFileAbsoluteName(name, origin string) string {
+	if name == "@" {
+		return strings.ToLower(origin)
+	}
+	if strings.HasSuffix(name, ".") {
+		return strings.ToLower(name)
+	}
+	return strings.ToLower(name + "." + origin)
+}
+
+// zoneFileRelativeName returns owner relative to zone, @ for the apex
+func zoneFileRelativeName(owner, zone string) (string, bool) {
+	owner = strings.TrimSuffix(owner, ".")
+	if owner == zone {
+		return "@", true
+	}
+	if strings.HasSuffix(owner, "."+zone) {
+		return strings.TrimSuffix(owner, "."+zone), true
+	}
+	return "", false
+}
+
+// zoneFileHostName expands a host name in record data, the trailing dot is dropped
+func zoneFileHostName(name, origin string) string {
+	return strings.TrimSuffix(zoneFileAbsoluteName(name, origin), ".")
+}
+
+// FormatZoneFile renders the records of zone as a zone file. Records of views other than
+// defaultView carry a view= comment that ParseZoneFile reads back.
+func FormatZoneFile(zone string, records []DnsRecord, defaultTTL int, defaultView string) string {
+	zone = strings.TrimSuffix(zone, ".")
+	sorted := make([]DnsRecord, len(records))
+	copy(sorted, records)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.View != b.View {
+			return a.View < b.View
+		}
+		return a.Value < b.Value
+	})
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "$ORIGIN %s.\n", zone)
+	fmt.Fprintf(&out, "$TTL %d\n", defaultTTL)
+	for _, record := range sorted {
+		recordType := strings.ToUpper(record.Type)
+		value := record.Value
+		switch recordType {
+		case "CNAME", "NS", "PTR":
+			value = zoneFileFQDN(value)
+		case "MX":
+			value = fmt.Sprintf("%d %s", record.MX, zoneFileFQDN(value))
+		case "TXT", "SPF":
+			value = NormalizeDnsRecordValue(recordType, value)
+		case "SRV":
+			if fields := strings.Fields(value); len(fields) == 4 {
+				fields[3] = zoneFileFQDN(fields[3])
+				value = strings.Join(fields, " ")
+			}
+		}
+
+		line := fmt.Sprintf("%-24s %-7d IN %-6s %s", record.Name, record.TTL, recordType, value)
+		if record.View != "" && record.View != defaultView {
+			line += " ; view=" + record.View
+		}
+		out.WriteString(line + "\n")
+	}
+	return out.String()
+}
+
+// zoneFileFQDN adds the trailing dot to a host name
+func zoneFileFQDN(name string) string {
+	if name == "" || strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
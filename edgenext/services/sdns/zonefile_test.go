@@ -0,0 +1,118 @@
+package sdns
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseZoneFile(t *testing.T) {
+	content := `$ORIGIN example.com.
+$TTL 1h
+@       IN SOA ns1.example.com. admin.example.com. (
+            2024010101 ; serial
+            3600 900 604800 300 )
+@       IN NS   ns1.example.net.
+@          A    192.0.2.1
+           MX   10 mail
+www  300 IN CNAME @
+mail IN 1d A    192.0.2.2 ; view=telecom
+txt        TXT  "v=spf1 include:_spf.example.com" " ~all"
+quoted     TXT  "say \"hi\"; bye"
+_sip._tcp  SRV  10 60 5060 sip
+$ORIGIN sub.example.com.
+api        AAAA 2001:db8::1
+ns         NS   ns1.other.org.
+`
+
+	records, err := ParseZoneFile(content, "example.com", 600, "any")
+	if err != nil {
+		t.Fatalf("ParseZoneFile failed: %v", err)
+	}
+
+	want := []ZoneFileRecord{
+		{Line: 7, Name: "@", Type: "A", View: "any", Value: "192.0.2.1", TTL: 3600},
+		{Line: 8, Name: "@", Type: "MX", View: "any", Value: "mail.example.com", MX: 10, TTL: 3600},
+		{Line: 9, Name: "www", Type: "CNAME", View: "any", Value: "example.com", TTL: 300},
+		{Line: 10, Name: "mail", Type: "A", View: "telecom", Value: "192.0.2.2", TTL: 86400},
+		{Line: 11, Name: "txt", Type: "TXT", View: "any", Value: `"v=spf1 include:_spf.example.com ~all"`, TTL: 3600},
+		{Line: 12, Name: "quoted", Type: "TXT", View: "any", Value: `"say \"hi\"; bye"`, TTL: 3600},
+		{Line: 13, Name: "_sip._tcp", Type: "SRV", View: "any", Value: "10 60 5060 sip.example.com", TTL: 3600},
+		{Line: 15, Name: "api.sub", Type: "AAAA", View: "any", Value: "2001:db8::1", TTL: 3600},
+		{Line: 16, Name: "ns.sub", Type: "NS", View: "any", Value: "ns1.other.org", TTL: 3600},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("unexpected records:\n got %+v\nwant %+v", records, want)
+	}
+}
+
+func TestParseZoneFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"outside zone", "www.other.org. A 192.0.2.1\n", "line 1: www.other.org is outside of zone example.com"},
+		{"include", "$INCLUDE other.zone\n", "line 1: $INCLUDE is not supported"},
+		{"missing data", "www IN A\n", "line 1: record without type or data"},
+		{"bad mx", "@ MX mail\n", "line 1: MX record requires a priority and a host name"},
+		{"no owner", "   A 192.0.2.1\n", "line 1: record without an owner name"},
+		{"unbalanced", "@ SOA ns admin ( 1 2 3 4 5\n", "unterminated quoted string or parenthesis"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseZoneFile(tt.content, "example.com", 600, "any")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestFormatZoneFileRoundTrip(t *testing.T) {
+	records := []DnsRecord{
+		{Name: "www", Type: "CNAME", View: "any", Value: "example.com", TTL: 600},
+		{Name: "@", Type: "MX", View: "any", Value: "mail.example.com", MX: 10, TTL: 600},
+		{Name: "@", Type: "TXT", View: "any", Value: strings.Repeat("a", 300) + `"`, TTL: 600},
+		{Name: "mail", Type: "A", View: "telecom", Value: "192.0.2.2", TTL: 300},
+	}
+
+	content := FormatZoneFile("example.com", records, 600, "any")
+	if !strings.HasPrefix(content, "$ORIGIN example.com.\n$TTL 600\n") {
+		t.Errorf("unexpected header:\n%s", content)
+	}
+
+	parsed, err := ParseZoneFile(content, "example.com", 600, "any")
+	if err != nil {
+		t.Fatalf("ParseZoneFile failed: %v\n%s", err, content)
+	}
+	if len(parsed) != len(records) {
+		t.Fatalf("expected %d records, got %d", len(records), len(parsed))
+	}
+	for _, record := range records {
+		found := false
+		for _, p := range parsed {
+			if p.Name == record.Name && p.Type == record.Type && p.View == record.View && p.Value == NormalizeDnsRecordValue(record.Type, record.Value) && p.MX == record.MX && p.TTL == record.TTL {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("record %+v was not read back:\n%s", record, content)
+		}
+	}
+}
+
+func TestParseZoneFileTTL(t *testing.T) {
+	tests := map[string]int{"3600": 3600, "1h30m": 5400, "1W": 604800, "2d": 172800}
+	for value, want := range tests {
+		if got, ok := parseZoneFileTTL(value); !ok || got != want {
+			t.Errorf("parseZoneFileTTL(%q) = %d, %v, want %d", value, got, ok, want)
+		}
+	}
+	for _, value := range []string{"A", "IN", "1h5", "h"} {
+		if _, ok := parseZoneFileTTL(value); ok {
+			t.Errorf("parseZoneFileTTL(%q) should fail", value)
+		}
+	}
+}
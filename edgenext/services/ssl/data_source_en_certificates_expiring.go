@@ -0,0 +1,382 @@
+package ssl
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/helper"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/services/scdn"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Certificate sources merged by the expiring certificates data source
+const (
+	certificateSourceCdn  = "cdn"
+	certificateSourceScdn = "scdn"
+)
+
+// Page sizes used to walk the certificate and domain lists
+const (
+	expiringCdnPageSize  = 500
+	expiringScdnPageSize = 100
+)
+
+// DataSourceEdgenextCertificatesExpiring data source listing CDN SSL and SCDN certificates that
+// expire soon
+func DataSourceEdgenextCertificatesExpiring() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCertificatesExpiringRead,
+
+		Schema: map[string]*schema.Schema{
+			"expires_within_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Only return certificates expiring within this many days, already expired certificates and certificates with an unknown expiry time are always included, default: 30",
+			},
+			"sources": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Certificate sources to query: cdn (SSL certificates) and scdn, default: both",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{certificateSourceCdn, certificateSourceScdn}, false),
+				},
+			},
+			"domain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return certificates with a covered or bound domain matching this glob, e.g. *.example.com",
+			},
+			"binding_state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "all",
+				ValidateFunc: validation.StringInSlice([]string{"all", "bound", "unbound"}, false),
+				Description:  "Filter by binding state: all, bound, unbound, default: all",
+			},
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Used to save results.",
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of matching certificates",
+			},
+			"certificates": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Matching certificates, those with an unknown expiry time first, then soonest expiry first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Certificate source: cdn or scdn",
+						},
+						"cert_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Certificate ID",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Certificate name",
+						},
+						"domains": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Domains covered by the certificate",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"bound_domains": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Domains the certificate is bound to",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"bound": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the certificate is bound to any domain",
+						},
+						"expiry_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Expiry time in RFC 3339 format, empty when the expiry time is unknown",
+						},
+						"expiry_unknown": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the API returned no parsable expiry time, e.g. for a certificate still being applied for",
+						},
+						"days_remaining": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Whole days until expiry, negative once expired, 0 when the expiry time is unknown",
+						},
+						"expired": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the certificate has expired",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// expiringCertificate is a certificate from either source with a normalised expiry time.
+// ExpiryUnknown is set when the API returned no parsable expiry time.
+type expiringCertificate struct {
+	Source        string
+	CertID        string
+	Name          string
+	Domains       []string
+	BoundDomains  []string
+	Bound         bool
+	Expiry        time.Time
+	ExpiryUnknown bool
+}
+
+func dataSourceCertificatesExpiringRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*connectivity.EdgeNextClient)
+
+	sources := []string{certificateSourceCdn, certificateSourceScdn}
+	if v, ok := d.GetOk("sources"); ok && v.(*schema.Set).Len() > 0 {
+		sources = make([]string, 0)
+		for _, source := range v.(*schema.Set).List() {
+			sources = append(sources, source.(string))
+		}
+		sort.Strings(sources)
+	}
+
+	log.Printf("[INFO] Querying expiring certificates from %v", sources)
+
+	all := make([]expiringCertificate, 0)
+	for _, source := range sources {
+		var certificates []expiringCertificate
+		var err error
+		switch source {
+		case certificateSourceCdn:
+			certificates, err = listCdnExpiringCertificates(NewSslCertificateService(client))
+		case certificateSourceScdn:
+			certificates, err = listScdnExpiringCertificates(scdn.NewScdnService(client))
+		}
+		if err != nil {
+			return err
+		}
+		all = append(all, certificates...)
+	}
+
+	now := time.Now()
+	matched := filterExpiringCertificates(all, now,
+		d.Get("expires_within_days").(int), d.Get("domain").(string), d.Get("binding_state").(string))
+
+	certificates := make([]map[string]interface{}, 0, len(matched))
+	ids := make([]string, 0, len(matched))
+	for _, cert := range matched {
+		certificate := map[string]interface{}{
+			"source":         cert.Source,
+			"cert_id":        cert.CertID,
+			"name":           cert.Name,
+			"domains":        cert.Domains,
+			"bound_domains":  cert.BoundDomains,
+			"bound":          cert.Bound,
+			"expiry_time":    "",
+			"expiry_unknown": cert.ExpiryUnknown,
+			"days_remaining": 0,
+			"expired":        false,
+		}
+		if !cert.ExpiryUnknown {
+			certificate["expiry_time"] = cert.Expiry.UTC().Format(time.RFC3339)
+			certificate["days_remaining"] = int(cert.Expiry.Sub(now).Hours() / 24)
+			certificate["expired"] = !cert.Expiry.After(now)
+		}
+		certificates = append(certificates, certificate)
+		ids = append(ids, cert.Source+":"+cert.CertID)
+	}
+
+	d.SetId(helper.DataResourceIdsHash(ids))
+	if err := d.Set("certificates", certificates); err != nil {
+		return fmt.Errorf("error setting certificates: %w", err)
+	}
+	if err := d.Set("total", len(certificates)); err != nil {
+		return fmt.Errorf("error setting total: %w", err)
+	}
+
+	// Write result to output file if specified
+	if outputFile := d.Get("output_file").(string); outputFile != "" {
+		outputData := map[string]interface{}{
+			"total":        len(certificates),
+			"certificates": certificates,
+		}
+		if err := helper.WriteToFile(d, outputData); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+	}
+
+	log.Printf("[INFO] Expiring certificates query successful, %d of %d certificates matched", len(certificates), len(all))
+	return nil
+}
+
+// filterExpiringCertificates returns the certificates matching the filters. Certificates with an
+// unknown expiry time always match the expiry filter and come first, the rest are sorted soonest
+// expiry first.
+func filterExpiringCertificates(certificates []expiringCertificate, now time.Time, withinDays int, domainGlob, bindingState string) []expiringCertificate {
+	deadline := now.Add(time.Duration(withinDays) * 24 * time.Hour)
+	matched := make([]expiringCertificate, 0)
+	for _, cert := range certificates {
+		if !cert.ExpiryUnknown && cert.Expiry.After(deadline) {
+			continue
+		}
+		if (bindingState == "bound" && !cert.Bound) || (bindingState == "unbound" && cert.Bound) {
+			continue
+		}
+		if domainGlob != "" && !matchAnyDomain(domainGlob, cert.Domains, cert.BoundDomains) {
+			continue
+		}
+		matched = append(matched, cert)
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].ExpiryUnknown != matched[j].ExpiryUnknown {
+			return matched[i].ExpiryUnknown
+		}
+		return matched[i].Expiry.Before(matched[j].Expiry)
+	})
+	return matched
+}
+
+func matchAnyDomain(glob string, lists ...[]string) bool {
+	glob = strings.ToLower(glob)
+	for _, list := range lists {
+		for _, domain := range list {
+			if ok, _ := path.Match(glob, strings.ToLower(domain)); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// listCdnExpiringCertificates lists all CDN SSL certificates
+func listCdnExpiringCertificates(service *SslCertificateService) ([]expiringCertificate, error) {
+	certificates := make([]expiringCertificate, 0)
+	for page := 1; ; page++ {
+		response, err := service.ListSslCertificates(page, expiringCdnPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, cert := range response.Data.List {
+			expiry, ok := helper.ParseCertificateTime(cert.CertExpireTime)
+			if !ok {
+				log.Printf("[WARN] SSL certificate %s has an unknown expiry time %q", cert.CertID, cert.CertExpireTime)
+			}
+			certificates = append(certificates, expiringCertificate{
+				Source:        certificateSourceCdn,
+				CertID:        cert.CertID,
+				Name:          cert.Name,
+				Domains:       cert.IncludeDomains,
+				BoundDomains:  cert.AssociatedDomains,
+				Bound:         len(cert.AssociatedDomains) > 0,
+				Expiry:        expiry,
+				ExpiryUnknown: !ok,
+			})
+		}
+		if len(response.Data.List) < expiringCdnPageSize || page*expiringCdnPageSize >= response.Data.TotalNumber {
+			return certificates, nil
+		}
+	}
+}
+
+// listScdnExpiringCertificates lists all SCDN certificates with the domains bound to them
+func listScdnExpiringCertificates(service *scdn.ScdnService) ([]expiringCertificate, error) {
+	certificates := make([]expiringCertificate, 0)
+	anyBound := false
+	for page := 1; ; page++ {
+		response, err := service.ListCertificates(scdn.CASelfListRequest{
+			Page:    page,
+			PerPage: expiringScdnPageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list SCDN certificates: %w", err)
+		}
+		for _, cert := range response.Data.List {
+			expiry, ok := helper.ParseCertificateTime(cert.IssuerExpiryTime)
+			if !ok {
+				// Certificates that are still being applied for have no expiry time yet
+				log.Printf("[DEBUG] SCDN certificate %s has an unknown expiry time %q", cert.ID, cert.IssuerExpiryTime)
+			}
+			anyBound = anyBound || cert.Binded
+			certificates = append(certificates, expiringCertificate{
+				Source:        certificateSourceScdn,
+				CertID:        cert.ID,
+				Name:          cert.CAName,
+				Domains:       cert.CADomain,
+				BoundDomains:  []string{},
+				Bound:         cert.Binded,
+				Expiry:        expiry,
+				ExpiryUnknown: !ok,
+			})
+		}
+		total, _ := strconv.Atoi(response.Data.Total)
+		if len(response.Data.List) < expiringScdnPageSize || page*expiringScdnPageSize >= total {
+			break
+		}
+	}
+
+	if !anyBound {
+		return certificates, nil
+	}
+
+	bound, err := listScdnDomainsByCertificate(service)
+	if err != nil {
+		return nil, err
+	}
+	for i := range certificates {
+		if domains, ok := bound[certificates[i].CertID]; ok {
+			certificates[i].BoundDomains = domains
+		}
+	}
+	return certificates, nil
+}
+
+// listScdnDomainsByCertificate returns the SCDN domain names keyed by the ID of their certificate
+func listScdnDomainsByCertificate(service *scdn.ScdnService) (map[string][]string, error) {
+	domains := make(map[string][]string)
+	for page := 1; ; page++ {
+		response, err := service.ListDomains(scdn.DomainListRequest{
+			Page:     page,
+			PageSize: expiringScdnPageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list SCDN domains: %w", err)
+		}
+		for _, domain := range response.Data.List {
+			if domain.CAID > 0 {
+				caID := strconv.Itoa(domain.CAID)
+				domains[caID] = append(domains[caID], domain.Domain)
+			}
+		}
+		if len(response.Data.List) < expiringScdnPageSize || page*expiringScdnPageSize >= response.Data.Total {
+			return domains, nil
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package ssl
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilterExpiringCertificates(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	certificates := []expiringCertificate{
+		{Source: "cdn", CertID: "1", Domains: []string{"a.example.com"}, Bound: true, Expiry: now.Add(20 * 24 * time.Hour)},
+		{Source: "scdn", CertID: "2", Domains: []string{"*.example.com"}, BoundDomains: []string{"shop.example.com"}, Bound: true, Expiry: now.Add(-24 * time.Hour)},
+		{Source: "scdn", CertID: "3", Domains: []string{"other.org"}, Expiry: now.Add(5 * 24 * time.Hour)},
+		{Source: "cdn", CertID: "4", Domains: []string{"b.example.com"}, Expiry: now.Add(60 * 24 * time.Hour)},
+		{Source: "scdn", CertID: "5", Domains: []string{"new.example.com"}, ExpiryUnknown: true},
+	}
+
+	tests := []struct {
+		name         string
+		withinDays   int
+		domainGlob   string
+		bindingState string
+		want         []string
+	}{
+		{"within window sorted by expiry", 30, "", "all", []string{"5", "2", "3", "1"}},
+		{"expired and unknown only", 0, "", "all", []string{"5", "2"}},
+		{"bound", 30, "", "bound", []string{"2", "1"}},
+		{"unbound", 90, "", "unbound", []string{"5", "3", "4"}},
+		{"glob matches covered domain", 90, "*.example.com", "all", []string{"5", "2", "1", "4"}},
+		{"glob matches bound domain", 90, "shop.*", "all", []string{"2"}},
+		{"glob is case insensitive", 90, "OTHER.ORG", "all", []string{"3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterExpiringCertificates(certificates, now, tt.withinDays, tt.domainGlob, tt.bindingState)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d certificates, got %d", len(tt.want), len(got))
+			}
+			for i, cert := range got {
+				if cert.CertID != tt.want[i] {
+					t.Errorf("certificate %d: expected %s, got %s", i, tt.want[i], cert.CertID)
+				}
+			}
+		})
+	}
+}
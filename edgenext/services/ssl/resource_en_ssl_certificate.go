@@ -1,30 +1,17 @@
 package ssl
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
-	"strings"
+	"time"
 
 	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/connectivity"
+	"github.com/edgenextapisdk/terraform-provider-edgenext/edgenext/helper"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-// suppressCertificateFormatDiff suppresses certificate format differences
-// When comparing certificate content, ignore newline format differences (\n vs real newlines)
-func suppressCertificateFormatDiff(k, old, new string, d *schema.ResourceData) bool {
-	// Normalize newlines: convert \n to real newlines
-	normalizedOld := strings.ReplaceAll(old, "\\n", "\n")
-	normalizedNew := strings.ReplaceAll(new, "\\n", "\n")
-
-	// Remove all whitespace characters for comparison
-	cleanOld := strings.ReplaceAll(strings.ReplaceAll(normalizedOld, "\n", ""), " ", "")
-	cleanNew := strings.ReplaceAll(strings.ReplaceAll(normalizedNew, "\n", ""), " ", "")
-
-	// If content is the same (ignoring format), suppress the difference
-	return cleanOld == cleanNew
-}
-
 func ResourceEdgenextSslCertificate() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceSslCertificateCreate,
@@ -36,6 +23,8 @@ func ResourceEdgenextSslCertificate() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: resourceSslCertificateCustomizeDiff,
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -46,15 +35,15 @@ func ResourceEdgenextSslCertificate() *schema.Resource {
 				Type:             schema.TypeString,
 				Required:         true,
 				Sensitive:        true,
-				Description:      "SSL certificate content",
-				DiffSuppressFunc: suppressCertificateFormatDiff,
+				Description:      "SSL certificate content in PEM format, leaf certificate first followed by its chain",
+				DiffSuppressFunc: helper.SuppressEquivalentCertificate,
 			},
 			"key": {
 				Type:             schema.TypeString,
 				Required:         true,
 				Sensitive:        true,
 				Description:      "SSL certificate private key content",
-				DiffSuppressFunc: suppressCertificateFormatDiff,
+				DiffSuppressFunc: helper.SuppressEquivalentPrivateKey,
 			},
 			"cert_id": {
 				Type:        schema.TypeString,
@@ -79,6 +68,34 @@ func ResourceEdgenextSslCertificate() *schema.Resource {
 				Computed:    true,
 				Description: "Certificate end time",
 			},
+			"subject": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Subject of the leaf certificate, parsed locally",
+			},
+			"issuer": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Issuer of the leaf certificate, parsed locally",
+			},
+			"sans": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Subject alternative names of the leaf certificate, parsed locally",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"not_after": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiry time of the leaf certificate in RFC 3339 format, parsed locally",
+			},
+			"fingerprint_sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 fingerprint of the leaf certificate, parsed locally",
+			},
 		},
 	}
 }
@@ -149,6 +166,8 @@ func resourceSslCertificateRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("certificate", response.Data.Certificate)
 	d.Set("key", response.Data.Key)
 
+	setSslCertificateInfo(d, d.Get("certificate").(string))
+
 	return nil
 }
 
@@ -221,3 +240,56 @@ func resourceSslCertificateDelete(d *schema.ResourceData, m interface{}) error {
 	log.Printf("[INFO] SSL certificate deleted successfully: %s", certID)
 	return nil
 }
+
+// resourceSslCertificateCustomizeDiff validates the certificate chain and key at plan time and
+// derives the certificate attributes locally
+func resourceSslCertificateCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	raw := d.GetRawConfig()
+	certificate := raw.GetAttr("certificate")
+	key := raw.GetAttr("key")
+	if !certificate.IsKnown() || !key.IsKnown() {
+		return setNewComputedSslCertificateInfo(d)
+	}
+	if certificate.IsNull() || key.IsNull() {
+		return nil
+	}
+
+	// An unchanged certificate is not validated again, so an existing one passing its expiry does not block plans
+	if d.Id() != "" && !d.HasChanges("certificate", "key") {
+		return nil
+	}
+
+	info, err := helper.ValidateCertificate(certificate.AsString(), key.AsString(), time.Now())
+	if err != nil {
+		return fmt.Errorf("invalid SSL certificate: %w", err)
+	}
+	for k, v := range info.Attributes() {
+		if err := d.SetNew(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setNewComputedSslCertificateInfo(d *schema.ResourceDiff) error {
+	for k := range (&helper.CertificateInfo{}).Attributes() {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setSslCertificateInfo derives the certificate attributes from the certificate content
+func setSslCertificateInfo(d *schema.ResourceData, certificate string) {
+	chain, err := helper.ParseCertificateChain(certificate)
+	if err != nil {
+		log.Printf("[WARN] Failed to parse SSL certificate %s: %v", d.Id(), err)
+		return
+	}
+	for k, v := range helper.GetCertificateInfo(chain).Attributes() {
+		if err := d.Set(k, v); err != nil {
+			log.Printf("[WARN] Failed to set %s: %v", k, err)
+		}
+	}
+}
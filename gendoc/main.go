@@ -820,13 +820,14 @@ func replace(input, from, to string) string {
 // getResourceDesc returns a friendly description for resources
 func getResourceDesc(resourceName string) string {
 	descriptions := map[string]string{
-		"edgenext_cdn_domain":      "CDN domain configuration",
-		"edgenext_cdn_purge":       "CDN cache purge tasks",
-		"edgenext_cdn_prefetch":    "CDN cache prefetch tasks",
-		"edgenext_ssl_certificate": "SSL certificates",
-		"edgenext_oss_bucket":      "OSS buckets",
-		"edgenext_oss_object":      "OSS objects",
-		"edgenext_oss_object_copy": "OSS object copy",
+		"edgenext_cdn_domain":          "CDN domain configuration",
+		"edgenext_cdn_purge":           "CDN cache purge tasks",
+		"edgenext_cdn_purge_on_change": "CDN purges of changed objects",
+		"edgenext_cdn_prefetch":        "CDN cache prefetch tasks",
+		"edgenext_ssl_certificate":     "SSL certificates",
+		"edgenext_oss_bucket":          "OSS buckets",
+		"edgenext_oss_object":          "OSS objects",
+		"edgenext_oss_object_copy":     "OSS object copy",
 		// ECS resources
 		"edgenext_ecs_key_pair":            "ECS key pairs",
 		"edgenext_ecs_vpc":                 "ECS VPC networks",
@@ -852,15 +853,21 @@ func getResourceDesc(resourceName string) string {
 		"edgenext_scdn_domain_access_mode":                        "SCDN domain access mode",
 		"edgenext_scdn_certificate":                               "SCDN certificates",
 		"edgenext_scdn_certificate_apply":                         "SCDN certificate application",
+		"edgenext_scdn_certificate_upload":                        "SCDN certificate file upload",
+		"edgenext_scdn_certificate_info":                          "SCDN certificate info",
+		"edgenext_scdn_certificate_batch_binding":                 "SCDN certificate batch binding",
+		"edgenext_scdn_certificate_batch_operation":               "SCDN certificate batch operation",
 		"edgenext_scdn_rule_template":                             "SCDN rule templates",
 		"edgenext_scdn_rule_template_domain_bind":                 "SCDN rule template domain bindings",
 		"edgenext_scdn_rule_template_domain_unbind":               "SCDN rule template domain unbindings",
 		"edgenext_scdn_network_speed_config":                      "SCDN network speed configuration",
 		"edgenext_scdn_network_speed_rule":                        "SCDN network speed rules",
 		"edgenext_scdn_network_speed_rules_sort":                  "SCDN network speed rules sorting",
+		"edgenext_scdn_network_speed_rules":                       "SCDN network speed rule lists",
 		"edgenext_scdn_cache_rule":                                "SCDN cache rules",
 		"edgenext_scdn_cache_rule_status":                         "SCDN cache rule status",
 		"edgenext_scdn_cache_rules_sort":                          "SCDN cache rules sorting",
+		"edgenext_scdn_cache_rules":                               "SCDN cache rule lists",
 		"edgenext_scdn_security_protection_ddos_config":           "SCDN DDoS protection configuration",
 		"edgenext_scdn_security_protection_waf_config":            "SCDN WAF protection configuration",
 		"edgenext_scdn_security_protection_template":              "SCDN security protection templates",
@@ -872,6 +879,7 @@ func getResourceDesc(resourceName string) string {
 		"edgenext_scdn_cache_clean_task":                          "SCDN cache clean tasks",
 		"edgenext_scdn_cache_preheat_task":                        "SCDN cache preheat tasks",
 		"edgenext_scdn_log_download_task":                         "SCDN log download tasks",
+		"edgenext_scdn_log_download_schedule":                     "SCDN log download schedules",
 		"edgenext_scdn_log_download_template":                     "SCDN log download templates",
 		"edgenext_scdn_log_download_template_status":              "SCDN log download template status",
 	}
@@ -889,17 +897,18 @@ func getResourceDesc(resourceName string) string {
 // getDataSourceDesc returns a friendly description for data sources
 func getDataSourceDesc(dataSourceName string) string {
 	descriptions := map[string]string{
-		"edgenext_cdn_domain":       "CDN domain configuration",
-		"edgenext_cdn_domains":      "CDN domains",
-		"edgenext_cdn_purge":        "CDN purge task details",
-		"edgenext_cdn_purges":       "CDN purge tasks",
-		"edgenext_cdn_prefetch":     "CDN prefetch task details",
-		"edgenext_cdn_prefetches":   "CDN prefetch tasks",
-		"edgenext_ssl_certificate":  "SSL certificate details",
-		"edgenext_ssl_certificates": "SSL certificates",
-		"edgenext_oss_buckets":      "OSS buckets",
-		"edgenext_oss_object":       "OSS object details",
-		"edgenext_oss_objects":      "OSS objects",
+		"edgenext_cdn_domain":            "CDN domain configuration",
+		"edgenext_cdn_domains":           "CDN domains",
+		"edgenext_cdn_purge":             "CDN purge task details",
+		"edgenext_cdn_purges":            "CDN purge tasks",
+		"edgenext_cdn_prefetch":          "CDN prefetch task details",
+		"edgenext_cdn_prefetches":        "CDN prefetch tasks",
+		"edgenext_ssl_certificate":       "SSL certificate details",
+		"edgenext_ssl_certificates":      "SSL certificates",
+		"edgenext_certificates_expiring": "Expiring certificates",
+		"edgenext_oss_buckets":           "OSS buckets",
+		"edgenext_oss_object":            "OSS object details",
+		"edgenext_oss_objects":           "OSS objects",
 		// ECS data sources
 		"edgenext_ecs_instances":            "ECS instances",
 		"edgenext_ecs_images":               "ECS images",